@@ -0,0 +1,209 @@
+package camera
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/drakstik/PhotoGnark_V1/src/photoproof"
+)
+
+// Every serialized Photograph starts with this magic string, followed by a single version byte.
+const photographMagic = "PGPH"
+
+// Version of the Photograph container written by WriteTo(). Version 2 holds the image, the proof,
+// the public witness and the fingerprint of the verifying key. Version 1 held the verifying key itself.
+// Version 3 adds the proofs of the photograph's edits.
+const PhotographVersion = 3
+
+// Upper bound on the number of edits read from a serialized Photograph.
+const maxEdits = 1 << 10
+
+func (photo Photograph) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := photo.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (photo *Photograph) UnmarshalBinary(data []byte) error {
+	_, err := photo.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// Writes the photograph as a versioned container. The verifying key is only referenced by its fingerprint,
+// so the reader needs the verifying key in its own key store to verify the photograph.
+func (photo Photograph) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(append([]byte(photographMagic), PhotographVersion))
+	total := int64(n)
+	if err != nil {
+		return total, err
+	}
+
+	m, err := photo.Img.WriteTo(w)
+	total += m
+	if err != nil {
+		return total, err
+	}
+
+	m, err = photo.writeProofs(w)
+	total += m
+
+	return total, err
+}
+
+func (photo *Photograph) ReadFrom(r io.Reader) (int64, error) {
+	header := make([]byte, len(photographMagic)+1)
+	n, err := io.ReadFull(r, header)
+	total := int64(n)
+	if err != nil {
+		return total, err
+	}
+	if string(header[:len(photographMagic)]) != photographMagic {
+		return total, fmt.Errorf("ERROR: not a serialized Photograph.")
+	}
+	version := header[len(photographMagic)]
+	if version < 1 || version > PhotographVersion {
+		return total, fmt.Errorf("ERROR: unsupported Photograph version %d.", version)
+	}
+
+	var read Photograph
+
+	m, err := read.Img.ReadFrom(r)
+	total += m
+	if err != nil {
+		return total, err
+	}
+
+	m, err = read.readProofs(r, version)
+	total += m
+	if err != nil {
+		return total, err
+	}
+
+	if version == 1 {
+		// The embedded verifying key is never trusted, only its fingerprint is kept
+		read.Proof.VerifyingKey_ID, err = embeddedVerifyingKeyID(read.Proof.VerifyingKey_ID)
+		if err != nil {
+			return total, err
+		}
+	}
+
+	*photo = read
+
+	return total, nil
+}
+
+// Returns the fingerprint of a verifying key embedded in a version 1 container.
+func embeddedVerifyingKeyID(vk_bytes []byte) ([]byte, error) {
+	vk := groth16.NewVerifyingKey(ecc.BN254)
+	if _, err := vk.ReadFrom(bytes.NewReader(vk_bytes)); err != nil {
+		return nil, fmt.Errorf("ERROR: reading verifying key: %w", err)
+	}
+
+	return photoproof.VerifyingKeyID(vk)
+}
+
+// Writes the proof of originality, followed by the number of edits and the proof of each edit.
+func (photo Photograph) writeProofs(w io.Writer) (int64, error) {
+	total, err := photo.Proof.WriteTo(w)
+	if err != nil {
+		return total, err
+	}
+
+	var count [4]byte
+	binary.BigEndian.PutUint32(count[:], uint32(len(photo.Edits)))
+	n, err := w.Write(count[:])
+	total += int64(n)
+	if err != nil {
+		return total, err
+	}
+
+	for _, edit := range photo.Edits {
+		m, err := edit.WriteTo(w)
+		total += m
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+// Reads the proofs written by writeProofs() into a container of the given version. Containers before version 3
+// end right after the proof of originality, in which case the photograph has no edits.
+func (photo *Photograph) readProofs(r io.Reader, version byte) (int64, error) {
+	total, err := photo.Proof.ReadFrom(r)
+	if err != nil {
+		return total, err
+	}
+
+	var count [4]byte
+	n, err := io.ReadFull(r, count[:])
+	total += int64(n)
+	if errors.Is(err, io.EOF) {
+		if version < 3 {
+			photo.Edits = nil
+			return total, nil
+		}
+		return total, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return total, err
+	}
+
+	nbEdits := binary.BigEndian.Uint32(count[:])
+	if nbEdits > maxEdits {
+		return total, fmt.Errorf("ERROR: serialized Photograph has %d edits, more than %d.", nbEdits, maxEdits)
+	}
+
+	photo.Edits = make([]photoproof.Gnark_Proof, nbEdits)
+	for i := range photo.Edits {
+		m, err := photo.Edits[i].ReadFrom(r)
+		total += m
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+// Writes the proofs embedded in a PNG's proof chunk: the container's magic and version, followed by writeProofs().
+func (photo Photograph) writeProofChunk(w io.Writer) (int64, error) {
+	n, err := w.Write(append([]byte(photographMagic), PhotographVersion))
+	total := int64(n)
+	if err != nil {
+		return total, err
+	}
+
+	m, err := photo.writeProofs(w)
+	total += m
+
+	return total, err
+}
+
+// Reads the proofs of a PNG's proof chunk written by writeProofChunk(). Chunks written before they had a header
+// hold the proofs alone and are read as version 2; they never start with the magic string, which read as the
+// length of the first proof blob would exceed its maximum size.
+func (photo *Photograph) readProofChunk(chunk []byte) error {
+	version := byte(2)
+
+	header := len(photographMagic) + 1
+	if len(chunk) >= header && string(chunk[:len(photographMagic)]) == photographMagic {
+		version = chunk[len(photographMagic)]
+		if version < 2 || version > PhotographVersion {
+			return fmt.Errorf("ERROR: unsupported proof chunk version %d.", version)
+		}
+		chunk = chunk[header:]
+	}
+
+	_, err := photo.readProofs(bytes.NewReader(chunk), version)
+	return err
+}
@@ -0,0 +1,111 @@
+package camera
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+	"github.com/drakstik/PhotoGnark_V1/src/photoproof"
+)
+
+// Returns a photo taken by a camera for small images, so its proof is quick.
+func newPhoto(t *testing.T) Photograph {
+	t.Helper()
+
+	cam := NewCamera([]photoproof.Transformation{photoproof.IdentityTransformation{}}, image.Size{Width: 4, Height: 4})
+	if cam.PCD_Keys == nil {
+		t.Fatal("could not generate the camera's PCD_Keys.")
+	}
+
+	photo, err := cam.Take_Random_Photo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return photo
+}
+
+func assertSameProof(t *testing.T, photo Photograph, read Photograph) {
+	t.Helper()
+
+	want, err := photo.Proof.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := read.Proof.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(want, got) || len(read.Edits) != len(photo.Edits) {
+		t.Fatal("read photograph does not hold the proofs that were written.")
+	}
+}
+
+func TestPhotographVersions(t *testing.T) {
+	photo := newPhoto(t)
+
+	b, err := photo.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var read Photograph
+	if err := read.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	assertSameProof(t, photo, read)
+
+	// Without its edit count, the container ends right after the proof of originality
+	truncated := b[:len(b)-4]
+	if err := read.UnmarshalBinary(truncated); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("a version %d container without an edit count should not be read: %v", PhotographVersion, err)
+	}
+
+	// ... which is how version 2 containers end
+	truncated[len(photographMagic)] = 2
+	if err := read.UnmarshalBinary(truncated); err != nil {
+		t.Fatal(err)
+	}
+	assertSameProof(t, photo, read)
+}
+
+func TestPhotographPNG(t *testing.T) {
+	photo := newPhoto(t)
+
+	var buf bytes.Buffer
+	if err := photo.WritePNG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadPNG(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameProof(t, photo, read)
+
+	// Chunks written before they had a header only hold the proof of originality
+	proof, err := photo.Proof.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err := image.EncodePNG(&buf, photo.Img, proof); err != nil {
+		t.Fatal(err)
+	}
+	read, err = ReadPNG(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameProof(t, photo, read)
+
+	// A chunk with a version 3 header must hold the edit count
+	chunk := append(append([]byte(photographMagic), PhotographVersion), proof...)
+	buf.Reset()
+	if err := image.EncodePNG(&buf, photo.Img, chunk); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadPNG(&buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("a version %d proof chunk without an edit count should not be read: %v", PhotographVersion, err)
+	}
+}
@@ -1,11 +1,61 @@
 package camera
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+
 	"github.com/drakstik/PhotoGnark_V1/src/image"
 	"github.com/drakstik/PhotoGnark_V1/src/photoproof"
 )
 
 type Photograph struct {
 	Img   image.Image
-	Proof photoproof.Gnark_Proof
+	Proof photoproof.Gnark_Proof   // Proof of originality of the photo taken by the camera
+	Edits []photoproof.Gnark_Proof // Proofs of the permissible transformations applied since, in order
+}
+
+// Applies a permissible transformation to the photograph and proves it, given the PCD keys generated for the edit.
+// The edit's input must be the photograph's current image. Returns the edited photograph; photo is unchanged.
+func (photo Photograph) Edit(edit photoproof.Edit, PCD_Keys map[string]photoproof.PCD_Keys) (Photograph, error) {
+	if !photoproof.SameCommitment(edit.Input(), photo.Img) {
+		return Photograph{}, fmt.Errorf("ERROR: the %s edit's input is not the photograph's image.", edit.GetType())
+	}
+
+	edit_proof, err := photoproof.Prove_Edit(edit, PCD_Keys)
+	if err != nil {
+		return Photograph{}, err
+	}
+
+	edits := append(append([]photoproof.Gnark_Proof{}, photo.Edits...), edit_proof)
+
+	return Photograph{Img: edit.Output(), Proof: photo.Proof, Edits: edits}, nil
+}
+
+// Writes the photograph as a PNG, with its serialized proofs embedded in a private ancillary chunk.
+func (photo Photograph) WritePNG(w io.Writer) error {
+	var proofs bytes.Buffer
+	if _, err := photo.writeProofChunk(&proofs); err != nil {
+		return err
+	}
+
+	return image.EncodePNG(w, photo.Img, proofs.Bytes())
+}
+
+// Reads a photograph written by WritePNG(). The returned photograph can be verified by a viewer.
+func ReadPNG(r io.Reader) (Photograph, error) {
+	img, proof_bytes, err := image.DecodePNG(r)
+	if err != nil {
+		return Photograph{}, err
+	}
+	if proof_bytes == nil {
+		return Photograph{}, fmt.Errorf("ERROR: PNG has no embedded proof.")
+	}
+
+	photo := Photograph{Img: img}
+	if err := photo.readProofChunk(proof_bytes); err != nil {
+		return Photograph{}, err
+	}
+
+	return photo, nil
 }
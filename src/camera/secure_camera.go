@@ -13,10 +13,12 @@ type SecureCamera struct {
 	Photographs   []Photograph
 	PermissibleTr []photoproof.Transformation
 	PCD_Keys      map[string]photoproof.PCD_Keys
+	Size          image.Size // Size of the camera's sensor; every photo it takes has this size
+	Sensor        Sensor     // Source of the frames photographed by Capture()
 }
 
-// Create a SecureCamera with permissible transformations
-func NewCamera(permissible []photoproof.Transformation) SecureCamera {
+// Create a SecureCamera with permissible transformations, taking photos of the given size
+func NewCamera(permissible []photoproof.Transformation, size image.Size) SecureCamera {
 
 	// Simulating a camera's secret key. NOT SECURE! Only for demo.
 	sk, err := photoproof.NewSecretKey()
@@ -25,7 +27,7 @@ func NewCamera(permissible []photoproof.Transformation) SecureCamera {
 	}
 
 	fmt.Println("Generating a new camera...")
-	pcd_keys, err := photoproof.Generator(sk, permissible)
+	pcd_keys, err := photoproof.Generator(sk, permissible, size)
 	if err != nil {
 		return SecureCamera{}
 	}
@@ -35,27 +37,107 @@ func NewCamera(permissible []photoproof.Transformation) SecureCamera {
 		Photographs:   []Photograph{},
 		PermissibleTr: permissible,
 		PCD_Keys:      pcd_keys,
+		Size:          size,
 	}
 
 	return camera
 }
 
+// Create a SecureCamera from PCD_Keys generated earlier (see photoproof.SavePCDKeys and photoproof.LoadPCDKeys),
+// instead of running the Generator again. Fails if a permissible transformation has no keys for the given size,
+// or if its keys were generated from another version of its circuit.
+func NewCameraWithKeys(permissible []photoproof.Transformation, size image.Size, pcd_keys map[string]photoproof.PCD_Keys) (SecureCamera, error) {
+	for _, tr := range permissible {
+		circuit, err := tr.NewCircuit(size)
+		if err != nil {
+			return SecureCamera{}, err
+		}
+
+		keys, ok := pcd_keys[circuit.GetType()]
+		if !ok {
+			return SecureCamera{}, fmt.Errorf("ERROR: no PCD_Keys for %s.", circuit.GetType())
+		}
+		if err := keys.CheckCircuit(circuit); err != nil {
+			return SecureCamera{}, err
+		}
+	}
+
+	// Simulating a camera's secret key. NOT SECURE! Only for demo.
+	sk, err := photoproof.NewSecretKey()
+	if err != nil {
+		return SecureCamera{}, err
+	}
+
+	camera := SecureCamera{
+		secretKey:     sk,
+		Photographs:   []Photograph{},
+		PermissibleTr: permissible,
+		PCD_Keys:      pcd_keys,
+		Size:          size,
+	}
+
+	return camera, nil
+}
+
+// Returns the camera's public key, which viewers use to check that a photograph was taken by this camera.
+func (camera SecureCamera) PublicKey() signature.PublicKey {
+	if camera.secretKey == nil {
+		return nil
+	}
+	return camera.secretKey.Public()
+}
+
 // This function takes a random image, proves its originality and stores
 // the image and proof as a photograph in the camera.
 // Also returns the photograph, for testing purposes.
 func (camera *SecureCamera) Take_Random_Photo() (Photograph, error) {
-	img, err := image.NewImage("random")
+	img, err := image.NewImage("random", camera.Size)
 	if err != nil {
 		return Photograph{}, fmt.Errorf("ERROR: NewImage() failed while taking a random photo.")
 	}
 
-	gnark_proof, err := photoproof.Prove_Originality(img, camera.secretKey, camera.PCD_Keys)
+	return camera.TakePhoto(img)
+}
+
+// Captures a frame from the camera's Sensor and photographs it.
+func (camera *SecureCamera) Capture() (Photograph, error) {
+	if camera.Sensor == nil {
+		return Photograph{}, fmt.Errorf("ERROR: camera has no sensor.")
+	}
+
+	frame, err := camera.Sensor.Capture(camera.Size)
+	if err != nil {
+		return Photograph{}, fmt.Errorf("ERROR: Sensor.Capture() failed while taking a photo: %w", err)
+	}
+
+	return camera.TakePhoto(frame)
+}
+
+// This function takes the given sensor frame, proves its originality and stores
+// the image and proof as a photograph in the camera.
+// The frame must have the camera's size; its commitment is recomputed rather than trusted.
+func (camera *SecureCamera) TakePhoto(frame image.Image) (Photograph, error) {
+	if frame.Size() != camera.Size {
+		return Photograph{}, fmt.Errorf("ERROR: %s frame given to a %s camera.", frame.Size(), camera.Size)
+	}
+
+	if err := frame.Validate(); err != nil {
+		return Photograph{}, err
+	}
+
+	b, err := frame.Commitment()
+	if err != nil {
+		return Photograph{}, err
+	}
+	frame.PixelBytes = b
+
+	gnark_proof, err := photoproof.Prove_Originality(frame, camera.secretKey, camera.PCD_Keys)
 	if err != nil {
-		return Photograph{}, fmt.Errorf("ERROR: Prove_Originality() failed while taking a random photo.")
+		return Photograph{}, fmt.Errorf("ERROR: Prove_Originality() failed while taking a photo.")
 	}
 
 	photo := Photograph{
-		Img:   img,
+		Img:   frame,
 		Proof: gnark_proof,
 	}
 
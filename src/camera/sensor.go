@@ -0,0 +1,52 @@
+package camera
+
+import (
+	"fmt"
+
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// A Sensor supplies the frames that a SecureCamera photographs.
+type Sensor interface {
+	Capture(size image.Size) (image.Image, error)
+}
+
+// Reads frames from PNG or JPEG files, one file per capture, in the given order.
+type FileSensor struct {
+	Paths []string
+	next  int
+}
+
+// Produces synthetic frames using one of image.NewImage()'s flags: "black", "white" or "random".
+type TestPatternSensor struct {
+	Pattern string
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewFileSensor(paths ...string) *FileSensor {
+	return &FileSensor{Paths: paths}
+}
+
+func (sensor *FileSensor) Capture(size image.Size) (image.Image, error) {
+	if sensor.next >= len(sensor.Paths) {
+		return image.Image{}, fmt.Errorf("ERROR: FileSensor has no more frames.")
+	}
+
+	path := sensor.Paths[sensor.next]
+	sensor.next++
+
+	frame, err := image.NewImageFromFile(path)
+	if err != nil {
+		return image.Image{}, err
+	}
+	if frame.Size() != size {
+		return image.Image{}, fmt.Errorf("ERROR: frame %s is %s, expected %s.", path, frame.Size(), size)
+	}
+
+	return frame, nil
+}
+
+func (sensor TestPatternSensor) Capture(size image.Size) (image.Image, error) {
+	return image.NewImage(sensor.Pattern, size)
+}
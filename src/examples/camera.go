@@ -2,6 +2,7 @@ package examples
 
 import (
 	"github.com/drakstik/PhotoGnark_V1/src/camera"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
 	"github.com/drakstik/PhotoGnark_V1/src/photoproof"
 )
 
@@ -17,7 +18,32 @@ func Test_New_Camera(permissible_transformations []string) camera.SecureCamera {
 		}
 	}
 
-	camera := camera.NewCamera(permissible)
+	camera := camera.NewCamera(permissible, image.Size{Width: image.N, Height: image.N})
 
 	return camera
 }
+
+// This tests a camera whose sensor is not square; its keys are generated for that size only.
+func Test_Camera_Size(width, height int) camera.SecureCamera {
+	permissible := []photoproof.Transformation{photoproof.IdentityTransformation{}}
+
+	return camera.NewCamera(permissible, image.Size{Width: width, Height: height})
+}
+
+// This tests SavePCDKeys() and LoadPCDKeys(): a second camera is created from the first camera's persisted keys,
+// without running the Generator again.
+func Test_Persist_Keys(dir string) (camera.SecureCamera, error) {
+	cam := Test_New_Camera([]string{"id"})
+
+	err := photoproof.SavePCDKeys(dir, cam.PCD_Keys)
+	if err != nil {
+		return camera.SecureCamera{}, err
+	}
+
+	pcd_keys, err := photoproof.LoadPCDKeys(dir)
+	if err != nil {
+		return camera.SecureCamera{}, err
+	}
+
+	return camera.NewCameraWithKeys(cam.PermissibleTr, cam.Size, pcd_keys)
+}
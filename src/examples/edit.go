@@ -0,0 +1,252 @@
+package examples
+
+import (
+	"bytes"
+
+	"github.com/drakstik/PhotoGnark_V1/src/camera"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+	"github.com/drakstik/PhotoGnark_V1/src/photoproof"
+	"github.com/drakstik/PhotoGnark_V1/src/viewer"
+)
+
+// Takes a photo with a camera for which tr is permissible, applies the edit built by newEdit and verifies the
+// edited photo as a viewer would. Shared by the examples of every edit transformation.
+func Test_Edit(tr photoproof.Transformation, newEdit func(img image.Image) (photoproof.Edit, error)) (bool, error) {
+	viewer_app_user, edited_photo, cam, err := editPhoto(tr, newEdit)
+	if err != nil {
+		return false, err
+	}
+
+	return viewer_app_user.VerifyPhotograph(edited_photo, cam.PublicKey())
+}
+
+// Returns the edited photo of Test_Edit(), along with a viewer trusting the keys of the camera that took it.
+func editPhoto(tr photoproof.Transformation, newEdit func(img image.Image) (photoproof.Edit, error)) (viewer.User, camera.Photograph, camera.SecureCamera, error) {
+	permissible := []photoproof.Transformation{photoproof.IdentityTransformation{}, tr}
+	cam := camera.NewCamera(permissible, image.Size{Width: image.N, Height: image.N})
+
+	photo, err := cam.Take_Random_Photo()
+	if err != nil {
+		return viewer.User{}, camera.Photograph{}, cam, err
+	}
+
+	edit, err := newEdit(photo.Img)
+	if err != nil {
+		return viewer.User{}, camera.Photograph{}, cam, err
+	}
+
+	edited_photo, err := photo.Edit(edit, cam.PCD_Keys)
+	if err != nil {
+		return viewer.User{}, camera.Photograph{}, cam, err
+	}
+
+	viewer_app_user, _ := viewer.NewUser()
+
+	err = viewer_app_user.Keys.AddPCDKeys(cam.PCD_Keys)
+	if err != nil {
+		return viewer.User{}, camera.Photograph{}, cam, err
+	}
+
+	return viewer_app_user, edited_photo, cam, nil
+}
+
+// This tests the Crop Transformation: an 8x10 rectangle is cropped out of a photo.
+func Test_Crop() (bool, error) {
+	crop := photoproof.CropTransformation{X: 4, Y: 2, Width: 8, Height: 10}
+
+	return Test_Edit(crop, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewCrop(img, crop.X, crop.Y, crop.Width, crop.Height)
+	})
+}
+
+// This tests the Brightness Transformation: every channel is darkened by 3, within the admin's bound of ±5.
+func Test_Brightness() (bool, error) {
+	brightness := photoproof.BrightnessTransformation{Bound: 5}
+
+	return Test_Edit(brightness, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewBrightness(img, -3, brightness.Bound, brightness.PublicDelta)
+	})
+}
+
+// This tests the Grayscale Transformation.
+func Test_Grayscale() (bool, error) {
+	return Test_Edit(photoproof.GrayscaleTransformation{}, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewGrayscale(img)
+	})
+}
+
+// This tests the Rotate Transformation: a photo is rotated by 90 degrees clockwise.
+func Test_Rotate() (bool, error) {
+	rotate := photoproof.RotateTransformation{Rotation: photoproof.Rotate90}
+
+	return Test_Edit(rotate, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewRotate(img, rotate.Rotation)
+	})
+}
+
+// This tests the Flip Transformation: a photo is mirrored left to right.
+func Test_Flip() (bool, error) {
+	flip := photoproof.FlipTransformation{Mode: photoproof.FlipHorizontal}
+
+	return Test_Edit(flip, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewFlip(img, flip.Mode)
+	})
+}
+
+// This tests the Redact Transformation: two private rectangles of a photo are blacked out.
+func Test_Redact() (bool, error) {
+	redact := photoproof.RedactTransformation{Count: 2}
+
+	return Test_Edit(redact, func(img image.Image) (photoproof.Edit, error) {
+		rects := []photoproof.Rect{{X: 2, Y: 3, Width: 5, Height: 4}, {X: 10, Y: 12, Width: 6, Height: 2}}
+		return photoproof.NewRedact(img, rects, [3]uint8{0, 0, 0}, redact.Count, redact.PublicRects)
+	})
+}
+
+// This tests the Pixelate Transformation: an 8x8 region of a photo is pixelated with 4x4 blocks.
+func Test_Pixelate() (bool, error) {
+	pixelate := photoproof.PixelateTransformation{Region: photoproof.Rect{X: 4, Y: 2, Width: 8, Height: 8}, Block: 4}
+
+	return Test_Edit(pixelate, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewPixelate(img, pixelate.Region, pixelate.Block)
+	})
+}
+
+// This tests the Resize Transformation: a photo is downsampled to a quarter of its width and height with a box filter.
+func Test_Resize() (bool, error) {
+	resize := photoproof.ResizeTransformation{Factor: 4, Filter: photoproof.BoxFilter}
+
+	return Test_Edit(resize, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewResize(img, resize.Factor, resize.Filter)
+	})
+}
+
+// This tests the Contrast Transformation: channels are stretched from 32..224 to 0..255.
+func Test_Contrast() (bool, error) {
+	contrast := photoproof.ContrastTransformation{Low: [3]uint8{32, 32, 32}, High: [3]uint8{224, 224, 224}}
+
+	return Test_Edit(contrast, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewContrast(img, contrast.Low, contrast.High)
+	})
+}
+
+// This tests the Curve Transformation with a gamma curve of 2.2. The viewer only accepts that curve, so it also
+// checks the LUT commitment of the verified edit.
+func Test_Gamma() (bool, error) {
+	lut, err := photoproof.GammaCurve(2.2)
+	if err != nil {
+		return false, err
+	}
+
+	viewer_app_user, edited_photo, cam, err := editPhoto(photoproof.CurveTransformation{}, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewCurve(img, lut)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// VerifyPhotograph() would verify the edits too, but they are needed for their LUT commitment
+	original, edits, err := viewer_app_user.VerifyEdits(edited_photo)
+	if err != nil {
+		return false, err
+	}
+
+	ok, err := viewer_app_user.VerifyOriginality(edited_photo, original, cam.PublicKey())
+	if !ok || err != nil {
+		return ok, err
+	}
+
+	got, err := edits[0].LUTCommitment()
+	if err != nil {
+		return false, err
+	}
+	want, err := lut.Commitment()
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(got, want), nil
+}
+
+// This tests the Invert Transformation.
+func Test_Invert() (bool, error) {
+	return Test_Edit(photoproof.InvertTransformation{}, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewInvert(img)
+	})
+}
+
+// This tests the Channel Swap Transformation: RGB channels are reordered to BGR.
+func Test_Channel_Swap() (bool, error) {
+	swap := photoproof.ChannelSwapTransformation{Order: photoproof.OrderBGR}
+
+	return Test_Edit(swap, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewChannelSwap(img, swap.Order)
+	})
+}
+
+// This tests the Convolution Transformation with a 3x3 Gaussian blur.
+func Test_Blur() (bool, error) {
+	blur := photoproof.ConvolutionTransformation{Kernel: photoproof.GaussianKernel(1)}
+
+	return Test_Edit(blur, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewConvolution(img, blur.Kernel)
+	})
+}
+
+// This tests the Perturbation Transformation: a photo is brightened by 2, which is within ±3 of the original.
+// Any other edit within the bound, e.g. re-encoding noise, is proven the same way.
+func Test_Perturbation() (bool, error) {
+	perturbation := photoproof.PerturbationTransformation{Epsilon: 3}
+
+	return Test_Edit(perturbation, func(img image.Image) (photoproof.Edit, error) {
+		brightness, err := photoproof.NewBrightness(img, 2, 2, false)
+		if err != nil {
+			return nil, err
+		}
+
+		return photoproof.NewPerturbation(img, brightness.Result, perturbation.Epsilon)
+	})
+}
+
+// This tests the Canvas Transformation: a photo is framed by a white border of 2 pixels.
+func Test_Canvas() (bool, error) {
+	canvas := photoproof.CanvasTransformation{X: 2, Y: 2, Width: image.N + 4, Height: image.N + 4}
+
+	return Test_Edit(canvas, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewCanvas(img, canvas.X, canvas.Y, canvas.Width, canvas.Height, [3]uint8{255, 255, 255})
+	})
+}
+
+// This tests the Watermark Transformation: a white 6x4 mark is blended at half opacity into the bottom-right corner.
+func Test_Watermark() (bool, error) {
+	mark, err := image.NewImage("white", image.Size{Width: 6, Height: 4})
+	if err != nil {
+		return false, err
+	}
+
+	watermark := photoproof.WatermarkTransformation{X: image.N - 7, Y: image.N - 5, Mark: mark, Alpha: 128}
+
+	return Test_Edit(watermark, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewWatermark(img, watermark.Mark, watermark.X, watermark.Y, watermark.Alpha)
+	})
+}
+
+// This tests the Quantize Transformation: every channel is reduced to 3 bits.
+func Test_Quantize() (bool, error) {
+	quantize := photoproof.QuantizeTransformation{Bits: 3}
+
+	return Test_Edit(quantize, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewQuantize(img, quantize.Bits)
+	})
+}
+
+// This tests the Palette Transformation with a black, white, red, green and blue palette.
+func Test_Palette() (bool, error) {
+	palette := photoproof.PaletteTransformation{
+		Palette: [][3]uint8{{0, 0, 0}, {255, 255, 255}, {255, 0, 0}, {0, 255, 0}, {0, 0, 255}},
+	}
+
+	return Test_Edit(palette, func(img image.Image) (photoproof.Edit, error) {
+		return photoproof.NewPalette(img, palette.Palette)
+	})
+}
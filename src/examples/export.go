@@ -0,0 +1,76 @@
+package examples
+
+import (
+	"os"
+
+	"github.com/drakstik/PhotoGnark_V1/src/camera"
+	"github.com/drakstik/PhotoGnark_V1/src/viewer"
+)
+
+// This tests WritePNG() and ReadPNG(): a photo is exported with its proof and verified again after reading it back.
+func Test_Export_PNG(path string) (bool, error) {
+	cam := Test_New_Camera([]string{"id"})
+
+	photo, err := cam.Take_Random_Photo()
+	if err != nil {
+		return false, err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return false, err
+	}
+	err = photo.WritePNG(f)
+	f.Close()
+	if err != nil {
+		return false, err
+	}
+
+	f, err = os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	read_photo, err := camera.ReadPNG(f)
+	if err != nil {
+		return false, err
+	}
+
+	viewer_app_user, _ := viewer.NewUser()
+
+	err = viewer_app_user.Keys.AddPCDKeys(cam.PCD_Keys)
+	if err != nil {
+		return false, err
+	}
+	return viewer_app_user.VerifyPhotograph(read_photo, cam.PublicKey())
+}
+
+// This tests the Photograph container: a photo is serialized, read back as another process would,
+// and verified with the camera's verifying keys.
+func Test_Serialize_Photograph() (bool, error) {
+	cam := Test_New_Camera([]string{"id"})
+
+	photo, err := cam.Take_Random_Photo()
+	if err != nil {
+		return false, err
+	}
+
+	data, err := photo.MarshalBinary()
+	if err != nil {
+		return false, err
+	}
+
+	var read_photo camera.Photograph
+	if err := read_photo.UnmarshalBinary(data); err != nil {
+		return false, err
+	}
+
+	viewer_app_user, _ := viewer.NewUser()
+
+	err = viewer_app_user.Keys.AddPCDKeys(cam.PCD_Keys)
+	if err != nil {
+		return false, err
+	}
+	return viewer_app_user.VerifyPhotograph(read_photo, cam.PublicKey())
+}
@@ -10,12 +10,12 @@ import (
 // This tests NewImage("random"), NewSecretKey(), NewIdentity(), Identity.Edit()
 // These can all be done by the SecureCamera!
 func Test_Identity_Transformation() {
-	img, err := image.NewImage("random")
+	img, err := image.NewImage("random", image.Size{Width: image.N, Height: image.N})
 	if err != nil {
 		return
 	}
 
-	img2, err := image.NewImage("random")
+	img2, err := image.NewImage("random", image.Size{Width: image.N, Height: image.N})
 	if err != nil {
 		return
 	}
@@ -0,0 +1,17 @@
+package examples
+
+import (
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// This tests NewImageFromFile() on a PNG or JPEG file.
+func Test_Import_Image(path string) (image.Image, error) {
+	img, err := image.NewImageFromFile(path)
+	if err != nil {
+		return image.Image{}, err
+	}
+
+	img.PrintImage()
+
+	return img, err
+}
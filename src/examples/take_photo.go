@@ -16,3 +16,20 @@ func Test_Take_Photo() camera.Photograph {
 	return photo
 
 }
+
+// This tests Capture() with a FileSensor: every frame is read from disk and proven original by the camera.
+func Test_Take_Photo_From_Files(paths ...string) ([]camera.Photograph, error) {
+	cam := Test_New_Camera([]string{"id"})
+	cam.Sensor = camera.NewFileSensor(paths...)
+
+	photos := []camera.Photograph{}
+	for range paths {
+		photo, err := cam.Capture()
+		if err != nil {
+			return photos, err
+		}
+		photos = append(photos, photo)
+	}
+
+	return photos, nil
+}
@@ -1,11 +1,76 @@
 package examples
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/drakstik/PhotoGnark_V1/src/photoproof"
 	"github.com/drakstik/PhotoGnark_V1/src/viewer"
 )
 
+// This tests VerifyPhotograph() against the camera that took the photo and against some other camera's key.
 func Test_Viewer() (bool, error) {
-	photo := Test_Take_Photo()
+	cam := Test_New_Camera([]string{"id"})
+
+	photo, err := cam.Take_Random_Photo()
+	if err != nil {
+		return false, err
+	}
+
 	viewer_app_user, _ := viewer.NewUser()
-	return viewer_app_user.VerifyPhotograph(photo)
+
+	// The viewer trusts the camera's verifying keys, e.g. after loading them with viewer.LoadKeyStore().
+	err = viewer_app_user.Keys.AddPCDKeys(cam.PCD_Keys)
+	if err != nil {
+		return false, err
+	}
+
+	// Simulating another camera's key; the photo must not verify against it.
+	other_sk, err := photoproof.NewSecretKey()
+	if err != nil {
+		return false, err
+	}
+
+	should_be_false, _ := viewer_app_user.VerifyPhotograph(photo, other_sk.Public())
+	fmt.Println(should_be_false)
+
+	return viewer_app_user.VerifyPhotograph(photo, cam.PublicKey())
+}
+
+// This tests the viewer's camera Registry: a photo is only accepted if one of the registered cameras took it.
+func Test_Registry() (viewer.RegisteredCamera, error) {
+	cam := Test_New_Camera([]string{"id"})
+
+	photo, err := cam.Take_Random_Photo()
+	if err != nil {
+		return viewer.RegisteredCamera{}, err
+	}
+
+	viewer_app_user, _ := viewer.NewUser()
+
+	err = viewer_app_user.Keys.AddPCDKeys(cam.PCD_Keys)
+	if err != nil {
+		return viewer.RegisteredCamera{}, err
+	}
+
+	// Before registering the camera, the photo comes from an unknown camera.
+	_, err = viewer_app_user.IdentifyCamera(photo)
+	fmt.Println(err)
+
+	err = viewer_app_user.Registry.Register(viewer.RegisteredCamera{
+		ID:           "cam-001",
+		Manufacturer: "PhotoGnark",
+		PublicKey:    cam.PublicKey(),
+		ValidFrom:    time.Now().Add(-time.Hour),
+		ValidUntil:   time.Now().Add(365 * 24 * time.Hour),
+		Status:       viewer.CameraActive,
+	})
+	if err != nil {
+		return viewer.RegisteredCamera{}, err
+	}
+
+	registered, err := viewer_app_user.IdentifyCamera(photo)
+	fmt.Println(registered.ID, err)
+
+	return registered, err
 }
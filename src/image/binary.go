@@ -0,0 +1,90 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Largest width or height accepted when reading an image.
+const MaxDimension = 1 << 14
+
+// Largest number of pixels accepted when reading an image, so a corrupt header cannot trigger a huge allocation:
+// the pixels of a 1024x1024 image already take about 40 MiB.
+const MaxPixels = 1 << 20
+
+// Serializes the image's width, height and RGB channels. Locations, packed values and the commitment are
+// derived from those on read, so they never have to be trusted.
+func (img Image) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := img.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (img *Image) UnmarshalBinary(data []byte) error {
+	_, err := img.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// Writes the width and height as big-endian uint32s, followed by the R, G and B bytes of every pixel in row-major order.
+func (img Image) WriteTo(w io.Writer) (int64, error) {
+	if err := img.Validate(); err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, 8, 8+3*len(img.Pixels))
+	binary.BigEndian.PutUint32(buf[0:4], uint32(img.Width))
+	binary.BigEndian.PutUint32(buf[4:8], uint32(img.Height))
+	for _, pxl := range img.Pixels {
+		buf = append(buf, pxl.RGB[0], pxl.RGB[1], pxl.RGB[2])
+	}
+
+	n, err := w.Write(buf)
+
+	return int64(n), err
+}
+
+func (img *Image) ReadFrom(r io.Reader) (int64, error) {
+	var header [8]byte
+	n, err := io.ReadFull(r, header[:])
+	if err != nil {
+		return int64(n), err
+	}
+
+	size := Size{Width: int(binary.BigEndian.Uint32(header[0:4])), Height: int(binary.BigEndian.Uint32(header[4:8]))}
+	if err := size.Validate(); err != nil {
+		return int64(n), err
+	}
+	if size.Width > MaxDimension || size.Height > MaxDimension {
+		return int64(n), fmt.Errorf("ERROR: image size %s exceeds %dx%d.", size, MaxDimension, MaxDimension)
+	}
+	if size.Width*size.Height > MaxPixels {
+		return int64(n), fmt.Errorf("ERROR: image size %s exceeds %d pixels.", size, MaxPixels)
+	}
+
+	channels := make([]byte, 3*size.Width*size.Height)
+	m, err := io.ReadFull(r, channels)
+	if err != nil {
+		return int64(n + m), err
+	}
+
+	newImage := Image{Width: size.Width, Height: size.Height, Pixels: make([]Pixel, size.Width*size.Height)}
+	for idx := range newImage.Pixels {
+		loc := PixelLocation{Row: uint64(idx / size.Width), Col: uint64(idx % size.Width), Idx: uint64(idx)}
+		newImage.Pixels[idx] = NewPixel([3]uint8{channels[3*idx], channels[3*idx+1], channels[3*idx+2]}, loc)
+	}
+
+	b, err := newImage.Commitment()
+	if err != nil {
+		return int64(n + m), err
+	}
+	newImage.PixelBytes = b
+
+	*img = newImage
+
+	return int64(n + m), nil
+}
@@ -0,0 +1,70 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	img, err := NewImage("random", Size{Width: 5, Height: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := img.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var read Image
+	if err := read.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if read.Size() != img.Size() || !bytes.Equal(read.PixelBytes, img.PixelBytes) {
+		t.Fatal("read image does not have the size and commitment of the written image.")
+	}
+	for idx, pxl := range img.Pixels {
+		if read.Pixels[idx] != pxl {
+			t.Fatalf("read pixel %d is %v, expected %v.", idx, read.Pixels[idx], pxl)
+		}
+	}
+}
+
+func TestBinaryTruncated(t *testing.T) {
+	img, err := NewImage("random", Size{Width: 5, Height: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := img.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var read Image
+	for _, length := range []int{0, 5, 8, len(b) - 1} {
+		if err := read.UnmarshalBinary(b[:length]); err == nil {
+			t.Fatalf("image truncated to %d bytes should not be read.", length)
+		}
+	}
+	if err := read.UnmarshalBinary(b[:5]); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("truncated header should be an unexpected EOF: %v", err)
+	}
+}
+
+func TestBinaryMaxPixels(t *testing.T) {
+	// Both headers are rejected before a single pixel is read, let alone allocated
+	for _, size := range []Size{{Width: MaxDimension, Height: MaxDimension}, {Width: MaxDimension + 1, Height: 1}} {
+		var header [8]byte
+		binary.BigEndian.PutUint32(header[0:4], uint32(size.Width))
+		binary.BigEndian.PutUint32(header[4:8], uint32(size.Height))
+
+		var read Image
+		if err := read.UnmarshalBinary(header[:]); err == nil || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("image of size %s should be rejected by its header: %v", size, err)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package image
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// Number of packed pixels (24 bits each) that fit in a single BN254 field element: 10 * 24 = 240 bits < 254 bits.
+// Packing several pixels per element keeps the commitment cheap without any pixel being reduced modulo the field.
+const PixelsPerElement = 10
+
+// Returns the image's packed pixels, in pixel index order, packed PixelsPerElement at a time into field elements.
+// Pixel j of a chunk occupies bits [24j, 24j+24) of its element.
+func (img Image) PackedElements() []fr.Element {
+	elements := make([]fr.Element, 0, (len(img.Pixels)+PixelsPerElement-1)/PixelsPerElement)
+
+	for start := 0; start < len(img.Pixels); start += PixelsPerElement {
+		chunk := new(big.Int)
+		for j := PixelsPerElement - 1; j >= 0; j-- {
+			chunk.Lsh(chunk, 24)
+			if start+j < len(img.Pixels) {
+				chunk.Or(chunk, big.NewInt(int64(img.Pixels[start+j].Packed)))
+			}
+		}
+
+		var e fr.Element
+		e.SetBigInt(chunk)
+		elements = append(elements, e)
+	}
+
+	return elements
+}
+
+// Returns a MiMC (BN254) commitment to the image's width, height and every pixel, as a Big Endian slice.
+// The same commitment is computed inside circuits by FrImage.Commitment(), so a signature over it authenticates the whole image.
+func (img Image) Commitment() ([]byte, error) {
+	if len(img.Pixels) != img.Width*img.Height {
+		return []byte{}, fmt.Errorf("ERROR: %s image has %d pixels.", img.Size(), len(img.Pixels))
+	}
+
+	hFunc := hash.MIMC_BN254.New()
+
+	var width, height fr.Element
+	width.SetUint64(uint64(img.Width))
+	height.SetUint64(uint64(img.Height))
+
+	elements := append([]fr.Element{width, height}, img.PackedElements()...)
+
+	for _, e := range elements {
+		b := e.Marshal()
+		if _, err := hFunc.Write(b); err != nil {
+			return []byte{}, err
+		}
+	}
+
+	return hFunc.Sum(nil), nil
+}
+
+// ---------------------------------------------------------------------------------------
+
+// Constrains the image to the size it is compiled for, and every pixel to be well formed: each channel fits in a byte,
+// Packed is the packing of RGB and Loc is the pixel's actual location. Without these constraints a prover could open
+// the commitment to pixels that do not exist.
+func (img FrImage) AssertWellFormed(api frontend.API) error {
+	if len(img.Pixels) != img.Size.Width*img.Size.Height {
+		return fmt.Errorf("ERROR: FrImage compiled for %s has %d pixels.", img.Size, len(img.Pixels))
+	}
+
+	api.AssertIsEqual(img.Width, img.Size.Width)
+	api.AssertIsEqual(img.Height, img.Size.Height)
+
+	for i := range img.Pixels {
+		pxl := img.Pixels[i]
+
+		for c := 0; c < 3; c++ {
+			api.ToBinary(pxl.RGB[c], 8)
+		}
+
+		api.AssertIsEqual(pxl.Packed, api.Add(api.Mul(pxl.RGB[0], 1<<16), api.Mul(pxl.RGB[1], 1<<8), pxl.RGB[2]))
+
+		api.AssertIsEqual(pxl.Loc.Idx, i)
+		api.AssertIsEqual(pxl.Loc.Row, i/img.Size.Width)
+		api.AssertIsEqual(pxl.Loc.Col, i%img.Size.Width)
+	}
+
+	return nil
+}
+
+// Computes the image's commitment in-circuit, the same way Image.Commitment() does natively.
+// Assumes the pixels have been constrained by AssertWellFormed().
+func (img FrImage) Commitment(api frontend.API) (frontend.Variable, error) {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return nil, err
+	}
+
+	hFunc.Write(img.Width, img.Height)
+
+	for start := 0; start < len(img.Pixels); start += PixelsPerElement {
+		var chunk frontend.Variable = 0
+		for j := PixelsPerElement - 1; j >= 0; j-- {
+			chunk = api.Mul(chunk, 1<<24)
+			if start+j < len(img.Pixels) {
+				chunk = api.Add(chunk, img.Pixels[start+j].Packed)
+			}
+		}
+		hFunc.Write(chunk)
+	}
+
+	return hFunc.Sum(), nil
+}
+
+// Constrains the image's pixels to be well formed and to open the public commitment ImgBytes.
+func (img FrImage) AssertCommitment(api frontend.API) error {
+	err := img.AssertWellFormed(api)
+	if err != nil {
+		return err
+	}
+
+	commitment, err := img.Commitment(api)
+	if err != nil {
+		return err
+	}
+
+	api.AssertIsEqual(commitment, img.ImgBytes)
+
+	return nil
+}
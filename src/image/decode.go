@@ -0,0 +1,83 @@
+package image
+
+import (
+	"fmt"
+	goimage "image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+)
+
+// Converts an image from Go's standard library into an Image. Alpha is dropped: each pixel keeps its
+// non-premultiplied 8-bit red, green and blue channels.
+func FromGoImage(src goimage.Image) (Image, error) {
+	bounds := src.Bounds()
+	size := Size{Width: bounds.Dx(), Height: bounds.Dy()}
+	if err := size.Validate(); err != nil {
+		return Image{}, err
+	}
+
+	newImage := Image{Width: size.Width, Height: size.Height, Pixels: make([]Pixel, size.Width*size.Height)}
+
+	for row := 0; row < size.Height; row++ {
+		for col := 0; col < size.Width; col++ {
+			c := color.NRGBAModel.Convert(src.At(bounds.Min.X+col, bounds.Min.Y+row)).(color.NRGBA)
+
+			// Translate the 2D location (x,y) into a 1D index.
+			idx := row*size.Width + col
+			loc := PixelLocation{Row: uint64(row), Col: uint64(col), Idx: uint64(idx)}
+
+			newImage.Pixels[idx] = NewPixel([3]uint8{c.R, c.G, c.B}, loc)
+		}
+	}
+
+	b, err := newImage.Commitment()
+	if err != nil {
+		return Image{}, err
+	}
+
+	newImage.PixelBytes = b
+
+	return newImage, err
+}
+
+// Decodes a PNG into an Image.
+func NewImageFromPNG(r io.Reader) (Image, error) {
+	src, err := png.Decode(r)
+	if err != nil {
+		return Image{}, fmt.Errorf("ERROR: decoding PNG: %w", err)
+	}
+
+	return FromGoImage(src)
+}
+
+// Decodes a JPEG into an Image.
+func NewImageFromJPEG(r io.Reader) (Image, error) {
+	src, err := jpeg.Decode(r)
+	if err != nil {
+		return Image{}, fmt.Errorf("ERROR: decoding JPEG: %w", err)
+	}
+
+	return FromGoImage(src)
+}
+
+// Decodes a PNG or JPEG file into an Image. The format is detected from the file's contents.
+func NewImageFromFile(path string) (Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Image{}, err
+	}
+	defer f.Close()
+
+	src, format, err := goimage.Decode(f)
+	if err != nil {
+		return Image{}, fmt.Errorf("ERROR: decoding %s: %w", path, err)
+	}
+	if format != "png" && format != "jpeg" {
+		return Image{}, fmt.Errorf("ERROR: %s is a %s image; only PNG and JPEG are supported.", path, format)
+	}
+
+	return FromGoImage(src)
+}
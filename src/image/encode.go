@@ -0,0 +1,144 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	goimage "image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// Type of the private ancillary PNG chunk that carries a serialized proof. Following the PNG spec:
+// lowercase 'p' = ancillary, lowercase 'g' = private, uppercase 'P' = reserved bit unset,
+// uppercase 'F' = unsafe to copy, since any edit to the pixels invalidates the proof.
+const ProofChunkType = "pgPF"
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// Converts the Image into an image from Go's standard library.
+func (img Image) ToGoImage() *goimage.NRGBA {
+	out := goimage.NewNRGBA(goimage.Rect(0, 0, img.Width, img.Height))
+
+	for _, pxl := range img.Pixels {
+		out.SetNRGBA(int(pxl.Loc.Col), int(pxl.Loc.Row), color.NRGBA{R: pxl.RGB[0], G: pxl.RGB[1], B: pxl.RGB[2], A: 255})
+	}
+
+	return out
+}
+
+// Writes the Image as a PNG.
+func (img Image) WritePNG(w io.Writer) error {
+	return EncodePNG(w, img, nil)
+}
+
+// Writes the Image as a PNG. If proof is not empty, it is embedded in a ProofChunkType chunk right before IEND,
+// so the proof travels with the file. Standard decoders ignore the chunk.
+func EncodePNG(w io.Writer, img Image, proof []byte) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img.ToGoImage()); err != nil {
+		return fmt.Errorf("ERROR: encoding PNG: %w", err)
+	}
+
+	encoded := buf.Bytes()
+	if len(proof) == 0 {
+		_, err := w.Write(encoded)
+		return err
+	}
+
+	// The IEND chunk is always the last 12 bytes: length (0), type and CRC.
+	iend := len(encoded) - 12
+	if iend < len(pngSignature) || string(encoded[iend+4:iend+8]) != "IEND" {
+		return fmt.Errorf("ERROR: encoded PNG does not end with IEND.")
+	}
+
+	if _, err := w.Write(encoded[:iend]); err != nil {
+		return err
+	}
+	if err := writeChunk(w, ProofChunkType, proof); err != nil {
+		return err
+	}
+	_, err := w.Write(encoded[iend:])
+
+	return err
+}
+
+// Decodes a PNG into an Image and returns the payload of its ProofChunkType chunk, or nil if it has none.
+func DecodePNG(r io.Reader) (Image, []byte, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Image{}, nil, err
+	}
+
+	proof, err := extractChunk(data, ProofChunkType)
+	if err != nil {
+		return Image{}, nil, err
+	}
+
+	img, err := NewImageFromPNG(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, nil, err
+	}
+
+	return img, proof, nil
+}
+
+// ----------------------------------------------------------------------------------------
+
+func writeChunk(w io.Writer, chunkType string, payload []byte) error {
+	header := make([]byte, 8)
+	binary.BigEndian.PutUint32(header[:4], uint32(len(payload)))
+	copy(header[4:], chunkType)
+
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:])
+	crc.Write(payload)
+
+	footer := make([]byte, 4)
+	binary.BigEndian.PutUint32(footer, crc.Sum32())
+
+	for _, b := range [][]byte{header, payload, footer} {
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Walks the chunks of an encoded PNG and returns the payload of its chunk of the given type, or nil if it has none.
+// A PNG with more than one such chunk is rejected, since it would be ambiguous which one to trust.
+func extractChunk(data []byte, chunkType string) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, fmt.Errorf("ERROR: not a PNG file.")
+	}
+
+	var payload []byte
+	for pos := len(pngSignature); pos+12 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		typ := string(data[pos+4 : pos+8])
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, fmt.Errorf("ERROR: truncated PNG chunk %q.", typ)
+		}
+
+		if typ == chunkType {
+			if payload != nil {
+				return nil, fmt.Errorf("ERROR: more than one PNG chunk %q.", typ)
+			}
+			if crc32.ChecksumIEEE(data[pos+4:pos+8+length]) != binary.BigEndian.Uint32(data[pos+8+length:end]) {
+				return nil, fmt.Errorf("ERROR: bad CRC in PNG chunk %q.", typ)
+			}
+			payload = append([]byte{}, data[pos+8:pos+8+length]...)
+		}
+		if typ == "IEND" {
+			break
+		}
+
+		pos = end
+	}
+
+	return payload, nil
+}
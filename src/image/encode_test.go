@@ -0,0 +1,88 @@
+package image
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Returns a random image encoded as a PNG carrying the given proof, along with the image.
+func encodedPNG(t *testing.T, proof []byte) ([]byte, Image) {
+	t.Helper()
+
+	img, err := NewImage("random", Size{Width: 5, Height: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := EncodePNG(&buf, img, proof); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes(), img
+}
+
+func TestPNGProofChunk(t *testing.T) {
+	proof := []byte("serialized proof")
+	data, img := encodedPNG(t, proof)
+
+	decoded, payload, err := DecodePNG(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(payload, proof) {
+		t.Fatalf("decoded proof is %q, expected %q.", payload, proof)
+	}
+	if decoded.Size() != img.Size() || !bytes.Equal(decoded.PixelBytes, img.PixelBytes) {
+		t.Fatal("decoded image is not the encoded image.")
+	}
+
+	// The chunk is ancillary, so standard decoders still read the image
+	if _, err := NewImageFromPNG(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPNGMissingProofChunk(t *testing.T) {
+	data, img := encodedPNG(t, nil)
+
+	decoded, payload, err := DecodePNG(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload != nil {
+		t.Fatalf("PNG without a proof chunk should decode without a proof, got %q.", payload)
+	}
+	if !bytes.Equal(decoded.PixelBytes, img.PixelBytes) {
+		t.Fatal("decoded image is not the encoded image.")
+	}
+}
+
+func TestPNGDuplicateProofChunk(t *testing.T) {
+	data, _ := encodedPNG(t, []byte("first proof"))
+
+	// Insert a second proof chunk right before IEND
+	iend := len(data) - 12
+	var buf bytes.Buffer
+	buf.Write(data[:iend])
+	if err := writeChunk(&buf, ProofChunkType, []byte("second proof")); err != nil {
+		t.Fatal(err)
+	}
+	buf.Write(data[iend:])
+
+	if _, _, err := DecodePNG(&buf); err == nil {
+		t.Fatal("PNG with two proof chunks should not be decoded.")
+	}
+}
+
+func TestPNGCorruptProofChunk(t *testing.T) {
+	proof := []byte("serialized proof")
+	data, _ := encodedPNG(t, proof)
+
+	// Flip a bit of the payload, which sits right before the chunk's CRC and the IEND chunk
+	data[len(data)-12-4-1] ^= 1
+
+	if _, _, err := DecodePNG(bytes.NewReader(data)); err == nil {
+		t.Fatal("PNG with a corrupt proof chunk should not be decoded.")
+	}
+}
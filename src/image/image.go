@@ -6,40 +6,73 @@ import (
 	"fmt"
 	"math/big"
 
-	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/consensys/gnark-crypto/hash"
 	"github.com/consensys/gnark-crypto/signature"
 	"github.com/consensys/gnark/frontend"
 )
 
 const (
-	// N must be a constant, not a variable, because N is used in the definition of the image size and
-	// circuits do not change once compiled. N is also used to set array size, which is set at compile time and is meant
-	// to remain unchangeable.
-	N  = 16
-	N2 = N * N // Number of pixels in an image.
+	// N is the default width and height of the demo camera's images.
+	// Circuits do not change once compiled, so every image size gets its own circuit and PCD keys (see photoproof.Generator).
+	N = 16
 )
 
+// Width and height of an image, in pixels.
+type Size struct {
+	Width  int
+	Height int
+}
+
 type Image struct {
-	Pixels     [N2]Pixel
-	PixelBytes []byte
+	Width      int
+	Height     int
+	Pixels     []Pixel // Width*Height pixels in row-major order, i.e. Pixels[i].Loc.Idx == i
+	PixelBytes []byte  // Commitment to the dimensions and pixels as a Big Endian slice; see Commitment()
 }
 
 type FrImage struct {
-	Pixels   [N2]FrPixel       `gnark:",secret"` // Secret
-	ImgBytes frontend.Variable `gnark:",secret"`
+	Width    frontend.Variable `gnark:",public"`
+	Height   frontend.Variable `gnark:",public"`
+	Pixels   []FrPixel         `gnark:",secret"` // Secret
+	ImgBytes frontend.Variable `gnark:",public"` // Commitment to the dimensions and pixels; see Image.Commitment()
+	Size     Size              `gnark:"-"`       // Dimensions the circuit is compiled for
 }
 
 // ---------------------------------------------------------------------------------------
 
-func NewImage(flag string) (Image, error) {
-	newImage := Image{Pixels: [N2]Pixel{}}
+func (size Size) String() string {
+	return fmt.Sprintf("%dx%d", size.Width, size.Height)
+}
+
+func (size Size) Validate() error {
+	if size.Width <= 0 || size.Height <= 0 {
+		return fmt.Errorf("ERROR: invalid image size %s.", size)
+	}
+
+	return nil
+}
+
+// Returns an image of the given size with every pixel set to black.
+func NewBlankImage(size Size) (Image, error) {
+	return NewImage("black", size)
+}
+
+func NewImage(flag string, size Size) (Image, error) {
+	if err := size.Validate(); err != nil {
+		return Image{}, err
+	}
+	if flag != "black" && flag != "white" && flag != "random" {
+		return Image{}, fmt.Errorf("ERROR: unknown image flag %q.", flag)
+	}
+
+	newImage := Image{Width: size.Width, Height: size.Height, Pixels: make([]Pixel, size.Width*size.Height)}
+
+	for row := 0; row < size.Height; row++ {
+		for col := 0; col < size.Width; col++ {
+			// Translate the 2D location (x,y) into a 1D index.
+			idx := row*size.Width + col
 
-	for row := 0; row < N; row++ {
-		for col := 0; col < N; col++ {
 			if flag == "black" {
-				// Translate the 2D location (x,y) into a 1D index.
-				idx := row*N + col
 				black := [3]uint8{0, 0, 0}
 
 				blackPixel := Pixel{
@@ -52,8 +85,6 @@ func NewImage(flag string) (Image, error) {
 			}
 
 			if flag == "white" {
-				// Translate the 2D location (x,y) into a 1D index.
-				idx := row*N + col
 				white := [3]uint8{255, 255, 255}
 
 				whitePixel := Pixel{
@@ -82,9 +113,6 @@ func NewImage(flag string) (Image, error) {
 
 				random := [3]uint8{uint8(n1.Int64()), uint8(n2.Int64()), uint8(n3.Int64())}
 
-				// Translate the 2D location (x,y) into a 1D index.
-				idx := row*N + col
-
 				randomPixel := Pixel{
 					RGB:    random,
 					Packed: Pack(random),
@@ -96,7 +124,7 @@ func NewImage(flag string) (Image, error) {
 		}
 	}
 
-	b, err := newImage.ToBigEndian()
+	b, err := newImage.Commitment()
 	if err != nil {
 		return Image{}, err
 	}
@@ -108,11 +136,42 @@ func NewImage(flag string) (Image, error) {
 
 // ----------------------------------------------------------------------------------------
 
+func (img Image) Size() Size {
+	return Size{Width: img.Width, Height: img.Height}
+}
+
+// Returns an error unless the image has Width*Height pixels in row-major order, each with a consistent Packed value.
+func (img Image) Validate() error {
+	if err := img.Size().Validate(); err != nil {
+		return err
+	}
+	if len(img.Pixels) != img.Width*img.Height {
+		return fmt.Errorf("ERROR: %s image has %d pixels.", img.Size(), len(img.Pixels))
+	}
+
+	for i, pxl := range img.Pixels {
+		loc := PixelLocation{Row: uint64(i / img.Width), Col: uint64(i % img.Width), Idx: uint64(i)}
+		if pxl.Loc != loc {
+			return fmt.Errorf("ERROR: pixel %d has location %v, expected %v.", i, pxl.Loc, loc)
+		}
+		if pxl.Packed != Pack(pxl.RGB) {
+			return fmt.Errorf("ERROR: pixel %d is packed as %d, expected %d.", i, pxl.Packed, Pack(pxl.RGB))
+		}
+	}
+
+	return nil
+}
+
+// Returns the pixel at the given row and column.
+func (img Image) At(row, col int) Pixel {
+	return img.Pixels[row*img.Width+col]
+}
+
 func (img Image) PrintImage() {
 	fmt.Println("RGB Image: ")
-	for row := 0; row < N; row++ {
-		for col := 0; col < N; col++ {
-			currentIdx := row*N + col
+	for row := 0; row < img.Height; row++ {
+		for col := 0; col < img.Width; col++ {
+			currentIdx := row*img.Width + col
 			pxl := img.Pixels[currentIdx]
 			fmt.Printf("(%3d, %3d, %3d) ", pxl.RGB[0], pxl.RGB[1], pxl.RGB[2])
 		}
@@ -120,15 +179,24 @@ func (img Image) PrintImage() {
 	}
 }
 
+// Returns an unassigned FrImage of the given size, used to compile circuits.
+func NewFrImage(size Size) FrImage {
+	return FrImage{Pixels: make([]FrPixel, size.Width*size.Height), Size: size}
+}
+
 func (img Image) ToFr() (frImg FrImage) {
 
-	output := FrImage{}
+	output := NewFrImage(img.Size())
 
-	for i := 0; i < N*N; i++ {
+	for i := 0; i < len(img.Pixels); i++ {
 		pxl := img.Pixels[i]
 		output.Pixels[pxl.Loc.Idx] = pxl.ToFr()
 	}
 
+	output.Width = img.Width
+	output.Height = img.Height
+	output.ImgBytes = img.PixelBytes
+
 	return output
 }
 
@@ -143,34 +211,13 @@ func (img Image) ToByte() ([]byte, error) {
 	return pixel_bytes, err
 }
 
-// Interprets pixel bytes as the bytes of a big-endian unsigned integer, sets z to that value, and return z value as a big endian slice.
-// If this step is skipped, you get this error:
-// "runtime error: slice bounds out of range".
-// This step is required to define an image into something that Gnark circuits understand.
-func (img Image) ToBigEndian() ([]byte, error) {
-
-	// Define the picture as a "z value of a field element (fr.element)" that's converted into a big endian
-	img_bytes, err := img.ToByte() // Encode image into bytes using JSON
-	if err != nil {
-		return []byte{}, err
-	}
-
-	var msgFr fr.Element // Define a field element
-
-	// (https://pkg.go.dev/github.com/consensys/gnark-crypto@v0.9.1/ecc/bn254/fr#Element.SetBytes)
-	msgFr.SetBytes(img_bytes)                 // Set the image bytes as the z value for the fr.Element
-	big_endian_bytes_Image := msgFr.Marshal() // Convert z value to a big endian slice
-
-	return big_endian_bytes_Image, err
-}
-
-// Simple digital signature of the image's PixelBytes.
+// Simple digital signature of the image's PixelBytes, i.e. of the commitment to its pixels.
 func (img Image) Sign(secretKey signature.Signer) ([]byte, error) {
 
 	// 3. Instantiate MIMC BN254 hash function, to be used in signing the image
 	hFunc := hash.MIMC_BN254.New()
 
-	// 4. Sign the image (must first turn the image into a Big Endian)
+	// 4. Sign the image (its PixelBytes are the Big Endian commitment to the pixels)
 	signature, err := secretKey.Sign(img.PixelBytes, hFunc)
 	if err != nil {
 		fmt.Println("Error while signing image: " + err.Error())
@@ -23,9 +23,9 @@ type FrPixelLoc struct {
 }
 
 type FrPixel struct {
-	RGB    frontend.Variable `gnark:",inherit"` //secret
-	Packed frontend.Variable `gnark:",inherit"` //secret
-	Loc    FrPixelLoc        `gnark:",inherit"` //secret
+	RGB    [3]frontend.Variable `gnark:",inherit"` //secret
+	Packed frontend.Variable    `gnark:",inherit"` //secret
+	Loc    FrPixelLoc           `gnark:",inherit"` //secret
 }
 
 // ---------------------------------------------------------------------------------------
@@ -38,7 +38,7 @@ func (pxl Pixel) ToFr() (frPxl FrPixel) {
 	}
 
 	return FrPixel{
-		RGB:    pxl.RGB,
+		RGB:    [3]frontend.Variable{pxl.RGB[0], pxl.RGB[1], pxl.RGB[2]},
 		Packed: pxl.Packed,
 		Loc:    loc,
 	}
@@ -12,4 +12,48 @@ func main() {
 
 	examples.Test_Viewer()
 
+	// examples.Test_Registry()
+
+	// examples.Test_Export_PNG("photo.png")
+
+	// examples.Test_Serialize_Photograph()
+
+	// examples.Test_Persist_Keys("keys")
+
+	// examples.Test_Crop()
+
+	// examples.Test_Brightness()
+
+	// examples.Test_Grayscale()
+
+	// examples.Test_Rotate()
+
+	// examples.Test_Flip()
+
+	// examples.Test_Redact()
+
+	// examples.Test_Pixelate()
+
+	// examples.Test_Resize()
+
+	// examples.Test_Contrast()
+
+	// examples.Test_Gamma()
+
+	// examples.Test_Invert()
+
+	// examples.Test_Channel_Swap()
+
+	// examples.Test_Blur()
+
+	// examples.Test_Perturbation()
+
+	// examples.Test_Canvas()
+
+	// examples.Test_Watermark()
+
+	// examples.Test_Quantize()
+
+	// examples.Test_Palette()
+
 }
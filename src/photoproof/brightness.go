@@ -0,0 +1,92 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// A Brightness Transformation adds Delta to every channel of every pixel, clamping the result to 0..255.
+// The admin sets the Bound on |Delta| (e.g. 5) and whether Delta is public, when the PCD keys are generated.
+// The Bound only holds for a single edit, so viewers accept at most one brightness edit per photograph.
+type BrightnessTransformation struct {
+	Delta       int
+	Bound       int  // Admin-configured bound on |Delta|
+	PublicDelta bool // Whether Delta is part of the public statement; otherwise only the bound is known
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewBrightness(img image.Image, delta int, bound int, publicDelta bool) (BrightnessTransformation, error) {
+	brightness := BrightnessTransformation{Delta: delta, Bound: bound, PublicDelta: publicDelta}
+	if err := brightness.checkBound(); err != nil {
+		return BrightnessTransformation{}, err
+	}
+	if delta < -bound || delta > bound {
+		return BrightnessTransformation{}, fmt.Errorf("ERROR: brightness delta %d is not within ±%d.", delta, bound)
+	}
+
+	brightened, err := mapPixels(img, func(pxl image.Pixel) [3]uint8 {
+		var rgb [3]uint8
+		for c := 0; c < 3; c++ {
+			rgb[c] = uint8(min(max(int(pxl.RGB[c])+delta, 0), 255))
+		}
+		return rgb
+	})
+	if err != nil {
+		return BrightnessTransformation{}, err
+	}
+
+	brightness.EditImages = EditImages{Img: img, Result: brightened}
+
+	return brightness, err
+}
+
+func (brightness BrightnessTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	if err := brightness.checkBound(); err != nil {
+		return nil, err
+	}
+
+	var public_delta int
+	if brightness.PublicDelta {
+		public_delta = brightness.Delta
+	}
+
+	circuit := &BrightnessCircuit{
+		EditCircuit:   brightness.ToFrEdit(),
+		Delta:         brightness.Delta,
+		Public_Delta:  public_delta,
+		Bound:         brightness.Bound,
+		DeltaIsPublic: brightness.PublicDelta,
+	}
+
+	return circuit, nil
+}
+
+func (brightness BrightnessTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	if err := brightness.checkBound(); err != nil {
+		return nil, err
+	}
+
+	circuit := &BrightnessCircuit{
+		EditCircuit:   NewEditCircuit(size, size),
+		Bound:         brightness.Bound,
+		DeltaIsPublic: brightness.PublicDelta,
+	}
+
+	return circuit, nil
+}
+
+func (brightness BrightnessTransformation) GetType() string {
+	return "brightness"
+}
+
+func (brightness BrightnessTransformation) checkBound() error {
+	if brightness.Bound < 0 || brightness.Bound > 255 {
+		return fmt.Errorf("ERROR: brightness bound %d is not within 0..255.", brightness.Bound)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Proves that every channel of Out is the matching channel of In plus Delta, clamped to 0..255, with |Delta| <= Bound.
+type BrightnessCircuit struct {
+	EditCircuit
+	Delta         frontend.Variable
+	Public_Delta  frontend.Variable `gnark:",public"` // Equal to Delta if DeltaIsPublic, 0 otherwise
+	Bound         int               `gnark:"-"`
+	DeltaIsPublic bool              `gnark:"-"`
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit BrightnessCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit BrightnessCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	if circuit.DeltaIsPublic {
+		api.AssertIsEqual(circuit.Public_Delta, circuit.Delta)
+	} else {
+		api.AssertIsEqual(circuit.Public_Delta, 0)
+	}
+
+	// -Bound <= Delta <= Bound
+	assertInRange(api, api.Add(circuit.Delta, circuit.Bound), 2*circuit.Bound)
+
+	for i := range circuit.Out.Pixels {
+		in := circuit.In.Pixels[i]
+		out := circuit.Out.Pixels[i]
+
+		for c := 0; c < 3; c++ {
+			// in + Delta lies in [-Bound, 255 + Bound]
+			brightened := clamp(api, api.Add(in.RGB[c], circuit.Delta), 0, 255, circuit.Bound)
+			api.AssertIsEqual(out.RGB[c], brightened)
+		}
+	}
+
+	return nil
+}
+
+func (circuit BrightnessCircuit) GetType() string {
+	visibility := "private"
+	if circuit.DeltaIsPublic {
+		visibility = "public"
+	}
+
+	return fmt.Sprintf("brightness_Fr_%s_bound%d_%s", circuit.sizes(), circuit.Bound, visibility)
+}
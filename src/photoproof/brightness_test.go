@@ -0,0 +1,68 @@
+package photoproof
+
+import (
+	"testing"
+
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// Returns an image whose channels lie next to both ends of 0..255, so that brightening and darkening clamp.
+func clampImage(t *testing.T) image.Image {
+	t.Helper()
+
+	return newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+		v := [4]uint8{0, 3, 252, 255}[col]
+		return [3]uint8{v, 255 - v, uint8(64 * row)}
+	})
+}
+
+func TestBrightnessClamp(t *testing.T) {
+	img := clampImage(t)
+
+	for _, delta := range []int{-5, 5} {
+		for _, publicDelta := range []bool{false, true} {
+			brightness, err := NewBrightness(img, delta, 5, publicDelta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertSolved(t, brightness)
+
+			// Channels that wrap around 0..255 instead of clamping
+			brightness.Result = newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+				rgb := img.At(row, col).RGB
+				return [3]uint8{uint8(int(rgb[0]) + delta), uint8(int(rgb[1]) + delta), uint8(int(rgb[2]) + delta)}
+			})
+			assertNotSolved(t, brightness)
+		}
+	}
+}
+
+func TestBrightnessBound(t *testing.T) {
+	img := randomImage(t, testSize)
+
+	// Brighten by 6 under a circuit compiled for a bound of 5
+	brightness, err := NewBrightness(img, 6, 6, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	brightness.Bound = 5
+
+	assertNotSolved(t, brightness)
+}
+
+func TestBrightnessPublicDelta(t *testing.T) {
+	img := randomImage(t, testSize)
+
+	brightness, err := NewBrightness(img, 2, 5, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The public delta must be the delta actually applied
+	err = solveWith(brightness, func(assignment TransformationCircuit) {
+		assignment.(*BrightnessCircuit).Public_Delta = 3
+	})
+	if err == nil {
+		t.Fatal("brightness edit with a wrong public delta should not be solved.")
+	}
+}
@@ -0,0 +1,96 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// A Canvas Transformation places the whole input on a Width x Height canvas filled with a Fill color, with the input's
+// top-left pixel at (X, Y). The input must fit in the canvas, so no pixel is lost: it is the reverse of a crop.
+// Shifting an image by (dx, dy) is placing it at (dx, dy) on a canvas dx wider and dy taller.
+type CanvasTransformation struct {
+	X      int // Column of the input's top-left pixel on the canvas
+	Y      int // Row of the input's top-left pixel on the canvas
+	Width  int // Canvas width
+	Height int // Canvas height
+	Fill   [3]uint8
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewCanvas(img image.Image, x int, y int, width int, height int, fill [3]uint8) (CanvasTransformation, error) {
+	canvas := CanvasTransformation{X: x, Y: y, Width: width, Height: height, Fill: fill}
+	if err := canvas.checkBounds(img.Size()); err != nil {
+		return CanvasTransformation{}, err
+	}
+
+	placement := canvas.rect(img.Size())
+
+	extended, err := newImageFrom(image.Size{Width: width, Height: height}, func(row int, col int) [3]uint8 {
+		if placement.Contains(row, col) {
+			return img.At(row-y, col-x).RGB
+		}
+		return fill
+	})
+	if err != nil {
+		return CanvasTransformation{}, err
+	}
+
+	canvas.EditImages = EditImages{Img: img, Result: extended}
+
+	return canvas, err
+}
+
+func (canvas CanvasTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	if err := canvas.checkBounds(canvas.Img.Size()); err != nil {
+		return nil, err
+	}
+
+	circuit := &CanvasCircuit{
+		EditCircuit: canvas.ToFrEdit(),
+		X:           canvas.X,
+		Y:           canvas.Y,
+		Fill:        image.Pack(canvas.Fill),
+		Placement:   canvas.rect(canvas.Img.Size()),
+	}
+
+	return circuit, nil
+}
+
+func (canvas CanvasTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	if err := canvas.checkBounds(size); err != nil {
+		return nil, err
+	}
+
+	circuit := &CanvasCircuit{
+		EditCircuit: NewEditCircuit(size, image.Size{Width: canvas.Width, Height: canvas.Height}),
+		Placement:   canvas.rect(size),
+	}
+
+	return circuit, nil
+}
+
+func (canvas CanvasTransformation) GetType() string {
+	return "canvas"
+}
+
+// Returns the rectangle covered by an input of the given size on the canvas.
+func (canvas CanvasTransformation) rect(size image.Size) Rect {
+	return Rect{X: canvas.X, Y: canvas.Y, Width: size.Width, Height: size.Height}
+}
+
+// Returns an error unless an input of the given size fits in the canvas at (X, Y).
+func (canvas CanvasTransformation) checkBounds(size image.Size) error {
+	if err := size.Validate(); err != nil {
+		return err
+	}
+
+	if canvas.X < 0 || canvas.Y < 0 || canvas.X+size.Width > canvas.Width || canvas.Y+size.Height > canvas.Height {
+		return fmt.Errorf("ERROR: a %s image at +%d+%d does not fit in a %dx%d canvas.", size, canvas.X, canvas.Y, canvas.Width, canvas.Height)
+	}
+
+	return nil
+}
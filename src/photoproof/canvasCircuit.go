@@ -0,0 +1,52 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Proves that the Placement rectangle of Out is exactly In, and that every other pixel of Out is Fill.
+type CanvasCircuit struct {
+	EditCircuit
+	X         frontend.Variable `gnark:",public"` // Column of the input's top-left pixel on the canvas
+	Y         frontend.Variable `gnark:",public"` // Row of the input's top-left pixel on the canvas
+	Fill      frontend.Variable `gnark:",public"` // Packed fill color
+	Placement Rect              `gnark:"-"`
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit CanvasCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit CanvasCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	api.AssertIsEqual(circuit.X, circuit.Placement.X)
+	api.AssertIsEqual(circuit.Y, circuit.Placement.Y)
+
+	in_width := circuit.In.Size.Width
+	out_width := circuit.Out.Size.Width
+
+	for i, out := range circuit.Out.Pixels {
+		row, col := i/out_width, i%out_width
+
+		if circuit.Placement.Contains(row, col) {
+			in := circuit.In.Pixels[(row-circuit.Placement.Y)*in_width+col-circuit.Placement.X]
+			api.AssertIsEqual(out.Packed, in.Packed)
+		} else {
+			api.AssertIsEqual(out.Packed, circuit.Fill)
+		}
+	}
+
+	return nil
+}
+
+func (circuit CanvasCircuit) GetType() string {
+	return fmt.Sprintf("canvas_Fr_%s+%d+%d", circuit.sizes(), circuit.Placement.X, circuit.Placement.Y)
+}
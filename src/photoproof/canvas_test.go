@@ -0,0 +1,49 @@
+package photoproof
+
+import "testing"
+
+func TestCanvas(t *testing.T) {
+	img := randomImage(t, testSize)
+	white := [3]uint8{255, 255, 255}
+
+	canvas, err := NewCanvas(img, 1, 2, 6, 7, white)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, canvas)
+
+	// The input is placed one column to the right
+	shifted, err := NewCanvas(img, 2, 2, 6, 7, white)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moved := canvas
+	moved.Result = shifted.Result
+	assertNotSolved(t, moved)
+
+	// The canvas is filled with another color
+	black, err := NewCanvas(img, 1, 2, 6, 7, [3]uint8{0, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	filled := canvas
+	filled.Result = black.Result
+	assertNotSolved(t, filled)
+}
+
+func TestCanvasPlacement(t *testing.T) {
+	img := randomImage(t, testSize)
+
+	canvas, err := NewCanvas(img, 1, 2, 6, 7, [3]uint8{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The public placement must be the one the circuit is compiled for
+	err = solveWith(canvas, func(assignment TransformationCircuit) {
+		assignment.(*CanvasCircuit).Y = 1
+	})
+	if err == nil {
+		t.Fatal("canvas edit with another public placement should not be solved.")
+	}
+}
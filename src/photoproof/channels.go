@@ -0,0 +1,74 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// Channel orders of common channel permutations: channel c of the output is channel Order[c] of the input.
+var (
+	OrderBGR = [3]int{2, 1, 0} // Fixes sensors that report BGR
+	OrderGRB = [3]int{1, 0, 2}
+	OrderBRG = [3]int{2, 0, 1}
+)
+
+// A Channel Swap Transformation permutes the channels of every pixel, e.g. RGB to BGR.
+type ChannelSwapTransformation struct {
+	Order [3]int // Channel c of the output is channel Order[c] of the input
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewChannelSwap(img image.Image, order [3]int) (ChannelSwapTransformation, error) {
+	swap := ChannelSwapTransformation{Order: order}
+	if err := swap.checkOrder(); err != nil {
+		return ChannelSwapTransformation{}, err
+	}
+
+	swapped, err := mapPixels(img, func(pxl image.Pixel) [3]uint8 {
+		return [3]uint8{pxl.RGB[order[0]], pxl.RGB[order[1]], pxl.RGB[order[2]]}
+	})
+	if err != nil {
+		return ChannelSwapTransformation{}, err
+	}
+
+	swap.EditImages = EditImages{Img: img, Result: swapped}
+
+	return swap, err
+}
+
+func (swap ChannelSwapTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	if err := swap.checkOrder(); err != nil {
+		return nil, err
+	}
+
+	return &ChannelSwapCircuit{EditCircuit: swap.ToFrEdit(), Order: swap.Order}, nil
+}
+
+func (swap ChannelSwapTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	if err := swap.checkOrder(); err != nil {
+		return nil, err
+	}
+
+	return &ChannelSwapCircuit{EditCircuit: NewEditCircuit(size, size), Order: swap.Order}, nil
+}
+
+func (swap ChannelSwapTransformation) GetType() string {
+	return "channelswap"
+}
+
+// Returns an error unless Order is a permutation of the channels 0, 1 and 2.
+func (swap ChannelSwapTransformation) checkOrder() error {
+	var seen [3]bool
+	for _, c := range swap.Order {
+		if c < 0 || c > 2 || seen[c] {
+			return fmt.Errorf("ERROR: channel order %v is not a permutation of the RGB channels.", swap.Order)
+		}
+		seen[c] = true
+	}
+
+	return nil
+}
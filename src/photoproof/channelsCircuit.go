@@ -0,0 +1,41 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Proves that channel c of every pixel of Out is channel Order[c] of the matching pixel of In.
+type ChannelSwapCircuit struct {
+	EditCircuit
+	Order [3]int `gnark:"-"`
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit ChannelSwapCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit ChannelSwapCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	for i := range circuit.Out.Pixels {
+		in := circuit.In.Pixels[i]
+		out := circuit.Out.Pixels[i]
+
+		for c := 0; c < 3; c++ {
+			api.AssertIsEqual(out.RGB[c], in.RGB[circuit.Order[c]])
+		}
+	}
+
+	return nil
+}
+
+func (circuit ChannelSwapCircuit) GetType() string {
+	return fmt.Sprintf("channelswap_Fr_%s_%d%d%d", circuit.sizes(), circuit.Order[0], circuit.Order[1], circuit.Order[2])
+}
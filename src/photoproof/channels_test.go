@@ -0,0 +1,36 @@
+package photoproof
+
+import "testing"
+
+func TestChannelSwap(t *testing.T) {
+	// Every pixel has three distinct channels
+	img := newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+		v := uint8(row*testSize.Width + col)
+		return [3]uint8{v, 100 + v, 200 + v}
+	})
+
+	orders := [][3]int{OrderBGR, OrderGRB, OrderBRG}
+	for _, order := range orders {
+		swap, err := NewChannelSwap(img, order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSolved(t, swap)
+
+		// The output of every other order
+		for _, other := range orders {
+			if other == order {
+				continue
+			}
+
+			swapped, err := NewChannelSwap(img, other)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tampered := swap
+			tampered.Result = swapped.Result
+			assertNotSolved(t, tampered)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// A Contrast Transformation linearly stretches each channel c so that Low[c] maps to 0 and High[c] maps to 255:
+// out = round((clamp(in, Low[c], High[c]) - Low[c]) * 255 / (High[c] - Low[c])), rounded as divRound does.
+type ContrastTransformation struct {
+	Low  [3]uint8
+	High [3]uint8
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewContrast(img image.Image, low [3]uint8, high [3]uint8) (ContrastTransformation, error) {
+	contrast := ContrastTransformation{Low: low, High: high}
+	if err := contrast.checkRange(); err != nil {
+		return ContrastTransformation{}, err
+	}
+
+	stretched, err := mapPixels(img, func(pxl image.Pixel) [3]uint8 {
+		var rgb [3]uint8
+		for c := 0; c < 3; c++ {
+			v := min(max(int(pxl.RGB[c]), int(low[c])), int(high[c]))
+			rgb[c] = uint8(divRoundInt((v-int(low[c]))*255, int(high[c])-int(low[c])))
+		}
+		return rgb
+	})
+	if err != nil {
+		return ContrastTransformation{}, err
+	}
+
+	contrast.EditImages = EditImages{Img: img, Result: stretched}
+
+	return contrast, err
+}
+
+func (contrast ContrastTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	if err := contrast.checkRange(); err != nil {
+		return nil, err
+	}
+
+	return &ContrastCircuit{EditCircuit: contrast.ToFrEdit(), Low: contrast.Low, High: contrast.High}, nil
+}
+
+func (contrast ContrastTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	if err := contrast.checkRange(); err != nil {
+		return nil, err
+	}
+
+	return &ContrastCircuit{EditCircuit: NewEditCircuit(size, size), Low: contrast.Low, High: contrast.High}, nil
+}
+
+func (contrast ContrastTransformation) GetType() string {
+	return "contrast"
+}
+
+func (contrast ContrastTransformation) checkRange() error {
+	for c := 0; c < 3; c++ {
+		if contrast.Low[c] >= contrast.High[c] {
+			return fmt.Errorf("ERROR: contrast range %d..%d of channel %d is empty.", contrast.Low[c], contrast.High[c], c)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Proves that every channel of Out is the matching channel of In, stretched from Low..High to 0..255.
+type ContrastCircuit struct {
+	EditCircuit
+	Low  [3]uint8 `gnark:"-"`
+	High [3]uint8 `gnark:"-"`
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit ContrastCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit ContrastCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	for i := range circuit.Out.Pixels {
+		in := circuit.In.Pixels[i]
+		out := circuit.Out.Pixels[i]
+
+		for c := 0; c < 3; c++ {
+			low, high := int(circuit.Low[c]), int(circuit.High[c])
+
+			// Channels lie in [0, 255], i.e. in [low - 255, high + 255]
+			v := clamp(api, in.RGB[c], low, high, 255)
+
+			stretched, err := divRound(api, api.Mul(api.Sub(v, low), 255), high-low, 255*(high-low))
+			if err != nil {
+				return err
+			}
+
+			api.AssertIsEqual(out.RGB[c], stretched)
+		}
+	}
+
+	return nil
+}
+
+func (circuit ContrastCircuit) GetType() string {
+	return fmt.Sprintf("contrast_Fr_%s_%d-%d_%d-%d_%d-%d", circuit.sizes(),
+		circuit.Low[0], circuit.High[0], circuit.Low[1], circuit.High[1], circuit.Low[2], circuit.High[2])
+}
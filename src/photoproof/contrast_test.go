@@ -0,0 +1,43 @@
+package photoproof
+
+import (
+	"testing"
+
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+func TestContrast(t *testing.T) {
+	low, high := [3]uint8{20, 30, 40}, [3]uint8{200, 210, 220}
+
+	// Column 0 is below Low, column 1 above High, and column 2 is 2 above Low, which stretches to 2*255/180 ~ 2.83
+	img := newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+		switch col {
+		case 0:
+			return [3]uint8{0, 0, 0}
+		case 1:
+			return [3]uint8{255, 255, 255}
+		case 2:
+			return [3]uint8{low[0] + 2, low[1] + 2, low[2] + 2}
+		}
+		return [3]uint8{uint8(64 * row), uint8(64 * row), uint8(64 * row)}
+	})
+
+	contrast, err := NewContrast(img, low, high)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, contrast)
+
+	bad := map[string]image.Image{
+		"not clamped to Low":  withPixel(t, contrast.Result, 0, 0, [3]uint8{1, 0, 0}),
+		"not clamped to High": withPixel(t, contrast.Result, 0, 1, [3]uint8{254, 255, 255}),
+		"truncated":           withPixel(t, contrast.Result, 0, 2, [3]uint8{2, 2, 2}),
+	}
+	for name, result := range bad {
+		tampered := contrast
+		tampered.Result = result
+		if err := solve(tampered); err == nil {
+			t.Fatalf("contrast edit %s should not be solved.", name)
+		}
+	}
+}
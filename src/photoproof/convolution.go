@@ -0,0 +1,162 @@
+package photoproof
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// Square convolution kernel of (2*Radius+1) x (2*Radius+1) non-negative integer weights, in row-major order.
+type Kernel struct {
+	Radius  int
+	Weights []int
+}
+
+// A Convolution Transformation replaces every channel of every pixel by the Kernel-weighted sum of its neighbourhood,
+// divided by the sum of the weights and rounded as divRound does, e.g. a box or Gaussian blur.
+// Edges are handled by replication: neighbours outside the image take the value of the nearest edge pixel.
+type ConvolutionTransformation struct {
+	Kernel Kernel
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+// Returns the kernel of a box blur: every neighbour within radius has the same weight.
+func BoxKernel(radius int) Kernel {
+	side := 2*radius + 1
+
+	weights := make([]int, side*side)
+	for i := range weights {
+		weights[i] = 1
+	}
+
+	return Kernel{Radius: radius, Weights: weights}
+}
+
+// Returns the binomial approximation of a Gaussian blur kernel, e.g. [1 2 1; 2 4 2; 1 2 1] for radius 1.
+func GaussianKernel(radius int) Kernel {
+	side := 2*radius + 1
+
+	// Row 2*radius of Pascal's triangle
+	binomial := make([]int, side)
+	binomial[0] = 1
+	for i := 1; i < side; i++ {
+		binomial[i] = binomial[i-1] * (side - i) / i
+	}
+
+	weights := make([]int, side*side)
+	for row := 0; row < side; row++ {
+		for col := 0; col < side; col++ {
+			weights[row*side+col] = binomial[row] * binomial[col]
+		}
+	}
+
+	return Kernel{Radius: radius, Weights: weights}
+}
+
+// Returns the weight of the neighbour at offset (dy, dx) from the center, for -Radius <= dy, dx <= Radius.
+func (kernel Kernel) At(dy int, dx int) int {
+	side := 2*kernel.Radius + 1
+	return kernel.Weights[(dy+kernel.Radius)*side+dx+kernel.Radius]
+}
+
+// Returns the sum of the weights, which every weighted sum is divided by.
+func (kernel Kernel) Divisor() int {
+	sum := 0
+	for _, w := range kernel.Weights {
+		sum += w
+	}
+
+	return sum
+}
+
+// Returns e.g. "3x3_1-2-1-2-4-2-1-2-1", recorded with the PCD_Keys of the kernel's circuit.
+func (kernel Kernel) String() string {
+	side := 2*kernel.Radius + 1
+
+	weights := make([]string, len(kernel.Weights))
+	for i, w := range kernel.Weights {
+		weights[i] = strconv.Itoa(w)
+	}
+
+	return fmt.Sprintf("%dx%d_%s", side, side, strings.Join(weights, "-"))
+}
+
+// Returns an error unless the kernel is square with non-negative weights and a positive divisor.
+func (kernel Kernel) Validate() error {
+	side := 2*kernel.Radius + 1
+	if kernel.Radius < 0 || len(kernel.Weights) != side*side {
+		return fmt.Errorf("ERROR: kernel of radius %d has %d weights.", kernel.Radius, len(kernel.Weights))
+	}
+
+	for _, w := range kernel.Weights {
+		if w < 0 {
+			return fmt.Errorf("ERROR: kernel weight %d is negative.", w)
+		}
+	}
+	if kernel.Divisor() == 0 {
+		return fmt.Errorf("ERROR: kernel weights sum to 0.")
+	}
+
+	return nil
+}
+
+// Returns the coordinate of the pixel replicated at coordinate v outside [0, n), i.e. v clamped to [0, n-1].
+func replicate(v int, n int) int {
+	return min(max(v, 0), n-1)
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewConvolution(img image.Image, kernel Kernel) (ConvolutionTransformation, error) {
+	if err := kernel.Validate(); err != nil {
+		return ConvolutionTransformation{}, err
+	}
+
+	convolved, err := newImageFrom(img.Size(), func(row int, col int) [3]uint8 {
+		var sum [3]int
+		for dy := -kernel.Radius; dy <= kernel.Radius; dy++ {
+			for dx := -kernel.Radius; dx <= kernel.Radius; dx++ {
+				pxl := img.At(replicate(row+dy, img.Height), replicate(col+dx, img.Width))
+				for c := 0; c < 3; c++ {
+					sum[c] += kernel.At(dy, dx) * int(pxl.RGB[c])
+				}
+			}
+		}
+
+		var rgb [3]uint8
+		for c := 0; c < 3; c++ {
+			rgb[c] = uint8(divRoundInt(sum[c], kernel.Divisor()))
+		}
+		return rgb
+	})
+	if err != nil {
+		return ConvolutionTransformation{}, err
+	}
+
+	return ConvolutionTransformation{Kernel: kernel, EditImages: EditImages{Img: img, Result: convolved}}, err
+}
+
+func (convolution ConvolutionTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	if err := convolution.Kernel.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &ConvolutionCircuit{EditCircuit: convolution.ToFrEdit(), Kernel: convolution.Kernel}, nil
+}
+
+func (convolution ConvolutionTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	if err := convolution.Kernel.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &ConvolutionCircuit{EditCircuit: NewEditCircuit(size, size), Kernel: convolution.Kernel}, nil
+}
+
+func (convolution ConvolutionTransformation) GetType() string {
+	return "convolution"
+}
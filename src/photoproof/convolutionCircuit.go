@@ -0,0 +1,66 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Proves that every channel of Out is the Kernel-weighted sum of the matching neighbourhood of In, with replicated
+// edges, divided by the Kernel's divisor.
+type ConvolutionCircuit struct {
+	EditCircuit
+	Kernel Kernel `gnark:"-"`
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit ConvolutionCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit ConvolutionCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	size := circuit.In.Size
+	kernel := circuit.Kernel
+	divisor := kernel.Divisor()
+
+	for i, out := range circuit.Out.Pixels {
+		row, col := i/size.Width, i%size.Width
+
+		for c := 0; c < 3; c++ {
+			var sum frontend.Variable = 0
+			for dy := -kernel.Radius; dy <= kernel.Radius; dy++ {
+				for dx := -kernel.Radius; dx <= kernel.Radius; dx++ {
+					in := circuit.In.Pixels[replicate(row+dy, size.Height)*size.Width+replicate(col+dx, size.Width)]
+					sum = api.Add(sum, api.Mul(in.RGB[c], kernel.At(dy, dx)))
+				}
+			}
+
+			// Weights are non-negative, so sum lies in [0, 255*divisor]
+			convolved, err := divRound(api, sum, divisor, 255*divisor)
+			if err != nil {
+				return err
+			}
+
+			api.AssertIsEqual(out.RGB[c], convolved)
+		}
+	}
+
+	return nil
+}
+
+func (circuit ConvolutionCircuit) GetType() string {
+	side := 2*circuit.Kernel.Radius + 1
+
+	return fmt.Sprintf("convolution_Fr_%s_%dx%d_%s", circuit.sizes(), side, side, fingerprint(circuit.params()))
+}
+
+// Implements parameterizedCircuit: the weights do not fit in the circuit type.
+func (circuit ConvolutionCircuit) params() string {
+	return circuit.Kernel.String()
+}
@@ -0,0 +1,63 @@
+package photoproof
+
+import "testing"
+
+func TestConvolutionEdges(t *testing.T) {
+	img := newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+		return [3]uint8{uint8(100 + 10*row + col), 200, uint8(150 - 10*col)}
+	})
+
+	for _, kernel := range []Kernel{BoxKernel(1), GaussianKernel(1)} {
+		convolution, err := NewConvolution(img, kernel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSolved(t, convolution)
+
+		// Convolved with zero padding instead of replicated edges
+		convolution.Result = newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+			var sum [3]int
+			for dy := -kernel.Radius; dy <= kernel.Radius; dy++ {
+				for dx := -kernel.Radius; dx <= kernel.Radius; dx++ {
+					r, c := row+dy, col+dx
+					if r < 0 || r >= testSize.Height || c < 0 || c >= testSize.Width {
+						continue
+					}
+					for i := 0; i < 3; i++ {
+						sum[i] += kernel.At(dy, dx) * int(img.At(r, c).RGB[i])
+					}
+				}
+			}
+
+			return [3]uint8{
+				uint8(divRoundInt(sum[0], kernel.Divisor())),
+				uint8(divRoundInt(sum[1], kernel.Divisor())),
+				uint8(divRoundInt(sum[2], kernel.Divisor())),
+			}
+		})
+		assertNotSolved(t, convolution)
+	}
+}
+
+func TestConvolutionType(t *testing.T) {
+	// Types name key files, so they must stay short however large the kernel is
+	large, err := ConvolutionTransformation{Kernel: GaussianKernel(10)}.NewCircuit(testSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(large.GetType()) > 64 {
+		t.Fatalf("circuit type %q is too long.", large.GetType())
+	}
+
+	box, err := ConvolutionTransformation{Kernel: BoxKernel(1)}.NewCircuit(testSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gaussian, err := ConvolutionTransformation{Kernel: GaussianKernel(1)}.NewCircuit(testSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if box.GetType() == gaussian.GetType() {
+		t.Fatalf("kernels of the same size share the circuit type %q.", box.GetType())
+	}
+}
@@ -0,0 +1,83 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// A Crop Transformation keeps the Width x Height rectangle of the input whose top-left pixel is at (X, Y).
+type CropTransformation struct {
+	X      int // Column of the rectangle's top-left pixel
+	Y      int // Row of the rectangle's top-left pixel
+	Width  int
+	Height int
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewCrop(img image.Image, x int, y int, width int, height int) (CropTransformation, error) {
+	crop := CropTransformation{X: x, Y: y, Width: width, Height: height}
+	if err := crop.checkBounds(img.Size()); err != nil {
+		return CropTransformation{}, err
+	}
+
+	cropped, err := newImageFrom(image.Size{Width: width, Height: height}, func(row int, col int) [3]uint8 {
+		return img.At(y+row, x+col).RGB
+	})
+	if err != nil {
+		return CropTransformation{}, err
+	}
+
+	crop.EditImages = EditImages{Img: img, Result: cropped}
+
+	return crop, err
+}
+
+func (crop CropTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	if err := crop.checkBounds(crop.Img.Size()); err != nil {
+		return nil, err
+	}
+
+	circuit := &CropCircuit{
+		EditCircuit: crop.ToFrEdit(),
+		X:           crop.X,
+		Y:           crop.Y,
+		Rect:        crop.rect(),
+	}
+
+	return circuit, nil
+}
+
+func (crop CropTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	if err := crop.checkBounds(size); err != nil {
+		return nil, err
+	}
+
+	circuit := &CropCircuit{
+		EditCircuit: NewEditCircuit(size, image.Size{Width: crop.Width, Height: crop.Height}),
+		Rect:        crop.rect(),
+	}
+
+	return circuit, nil
+}
+
+func (crop CropTransformation) GetType() string {
+	return "crop"
+}
+
+func (crop CropTransformation) rect() Rect {
+	return Rect{X: crop.X, Y: crop.Y, Width: crop.Width, Height: crop.Height}
+}
+
+// Returns an error unless the crop rectangle is not empty and lies within an image of the given size.
+func (crop CropTransformation) checkBounds(size image.Size) error {
+	if crop.Width <= 0 || crop.Height <= 0 || crop.X < 0 || crop.Y < 0 ||
+		crop.X+crop.Width > size.Width || crop.Y+crop.Height > size.Height {
+		return fmt.Errorf("ERROR: crop rectangle %dx%d+%d+%d does not fit in a %s image.", crop.Width, crop.Height, crop.X, crop.Y, size)
+	}
+
+	return nil
+}
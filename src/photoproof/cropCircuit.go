@@ -0,0 +1,50 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Proves that Out is exactly the Rect sub-region of In, where In opens a previously authenticated commitment.
+type CropCircuit struct {
+	EditCircuit
+	X    frontend.Variable `gnark:",public"` // Column of the rectangle's top-left pixel
+	Y    frontend.Variable `gnark:",public"` // Row of the rectangle's top-left pixel
+	Rect Rect              `gnark:"-"`
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit CropCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit CropCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	api.AssertIsEqual(circuit.X, circuit.Rect.X)
+	api.AssertIsEqual(circuit.Y, circuit.Rect.Y)
+
+	in_width := circuit.In.Size.Width
+
+	// Every output pixel is the input pixel at the same offset from the rectangle's corner.
+	// Pixels are compared by their packed value, which AssertImages() binds to the RGB channels.
+	for row := 0; row < circuit.Rect.Height; row++ {
+		for col := 0; col < circuit.Rect.Width; col++ {
+			out := circuit.Out.Pixels[row*circuit.Rect.Width+col]
+			in := circuit.In.Pixels[(circuit.Rect.Y+row)*in_width+circuit.Rect.X+col]
+
+			api.AssertIsEqual(out.Packed, in.Packed)
+		}
+	}
+
+	return nil
+}
+
+func (circuit CropCircuit) GetType() string {
+	return fmt.Sprintf("crop_Fr_%s+%d+%d", circuit.sizes(), circuit.Rect.X, circuit.Rect.Y)
+}
@@ -0,0 +1,49 @@
+package photoproof
+
+import "testing"
+
+func TestCrop(t *testing.T) {
+	img := randomImage(t, testSize)
+
+	// The rectangle reaches the last row and column of the input
+	crop, err := NewCrop(img, 1, 2, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, crop)
+}
+
+func TestCropShifted(t *testing.T) {
+	img := randomImage(t, testSize)
+
+	crop, err := NewCrop(img, 0, 0, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The output is the rectangle one column to the right of the one the circuit is compiled for
+	shifted, err := NewCrop(img, 1, 0, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	crop.Result = shifted.Result
+
+	assertNotSolved(t, crop)
+}
+
+func TestCropCorner(t *testing.T) {
+	img := randomImage(t, testSize)
+
+	crop, err := NewCrop(img, 1, 2, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The public corner must be the one the circuit is compiled for
+	err = solveWith(crop, func(assignment TransformationCircuit) {
+		assignment.(*CropCircuit).X = 2
+	})
+	if err == nil {
+		t.Fatal("crop edit with another public corner should not be solved.")
+	}
+}
@@ -0,0 +1,104 @@
+package photoproof
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// Lookup table mapping every channel value to a new value, e.g. a gamma curve.
+type LUT [256]uint8
+
+// Number of LUT entries (8 bits each) packed into a single BN254 field element by LUT.Commitment().
+const LUTEntriesPerElement = 30
+
+// A Curve Transformation maps every channel of every pixel through a LUT, e.g. a gamma curve (see GammaCurve).
+// The LUT is private, but its commitment is part of the public statement, so viewers can check which curve was
+// applied by comparing it with LUT.Commitment() of the curves they accept. A single circuit serves every LUT.
+type CurveTransformation struct {
+	LUT LUT
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+// Returns the LUT of the gamma curve out = 255 * (in/255)^(1/gamma), rounded to the nearest integer.
+func GammaCurve(gamma float64) (LUT, error) {
+	if !(gamma > 0) || math.IsInf(gamma, 0) {
+		return LUT{}, fmt.Errorf("ERROR: invalid gamma %v.", gamma)
+	}
+
+	var lut LUT
+	for v := range lut {
+		lut[v] = uint8(math.Round(255 * math.Pow(float64(v)/255, 1/gamma)))
+	}
+
+	return lut, nil
+}
+
+// Returns a MiMC (BN254) commitment to the LUT's entries, as a Big Endian slice.
+// The same commitment is computed inside the CurveCircuit, which makes it public.
+func (lut LUT) Commitment() ([]byte, error) {
+	hFunc := hash.MIMC_BN254.New()
+
+	for start := 0; start < len(lut); start += LUTEntriesPerElement {
+		// Entry j of a chunk occupies bits [8j, 8j+8) of its element
+		chunk := new(big.Int)
+		for j := LUTEntriesPerElement - 1; j >= 0; j-- {
+			chunk.Lsh(chunk, 8)
+			if start+j < len(lut) {
+				chunk.Or(chunk, big.NewInt(int64(lut[start+j])))
+			}
+		}
+
+		var e fr.Element
+		e.SetBigInt(chunk)
+
+		b := e.Marshal()
+		if _, err := hFunc.Write(b); err != nil {
+			return []byte{}, err
+		}
+	}
+
+	return hFunc.Sum(nil), nil
+}
+
+func NewCurve(img image.Image, lut LUT) (CurveTransformation, error) {
+	mapped, err := mapPixels(img, func(pxl image.Pixel) [3]uint8 {
+		return [3]uint8{lut[pxl.RGB[0]], lut[pxl.RGB[1]], lut[pxl.RGB[2]]}
+	})
+	if err != nil {
+		return CurveTransformation{}, err
+	}
+
+	return CurveTransformation{LUT: lut, EditImages: EditImages{Img: img, Result: mapped}}, err
+}
+
+func (curve CurveTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	commitment, err := curve.LUT.Commitment()
+	if err != nil {
+		return nil, err
+	}
+
+	circuit := newCurveCircuit(curve.ToFrEdit())
+	circuit.LUT_Commitment = commitment
+
+	for v, entry := range curve.LUT {
+		circuit.LUT[v] = entry
+	}
+
+	return circuit, nil
+}
+
+func (curve CurveTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	return newCurveCircuit(NewEditCircuit(size, size)), nil
+}
+
+func (curve CurveTransformation) GetType() string {
+	return "curve"
+}
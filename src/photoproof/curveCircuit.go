@@ -0,0 +1,80 @@
+package photoproof
+
+import (
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+)
+
+// Proves that every channel of Out is the LUT entry of the matching channel of In, where the LUT opens the public
+// LUT_Commitment.
+type CurveCircuit struct {
+	EditCircuit
+	LUT            []frontend.Variable // 256 entries
+	LUT_Commitment frontend.Variable   `gnark:",public"` // See LUT.Commitment()
+}
+
+func newCurveCircuit(images EditCircuit) *CurveCircuit {
+	return &CurveCircuit{EditCircuit: images, LUT: make([]frontend.Variable, len(LUT{}))}
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit CurveCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit CurveCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	// Every entry fits in a byte, so that packing entries into field elements is injective
+	for _, entry := range circuit.LUT {
+		api.ToBinary(entry, 8)
+	}
+
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(circuit.LUT); start += LUTEntriesPerElement {
+		var chunk frontend.Variable = 0
+		for j := LUTEntriesPerElement - 1; j >= 0; j-- {
+			chunk = api.Mul(chunk, 1<<8)
+			if start+j < len(circuit.LUT) {
+				chunk = api.Add(chunk, circuit.LUT[start+j])
+			}
+		}
+		hFunc.Write(chunk)
+	}
+
+	api.AssertIsEqual(hFunc.Sum(), circuit.LUT_Commitment)
+
+	// A log-derivative lookup costs a few constraints per query, instead of a 256-way selection per channel
+	table := logderivlookup.New(api)
+	for _, entry := range circuit.LUT {
+		table.Insert(entry)
+	}
+
+	channels := make([]frontend.Variable, 0, 3*len(circuit.In.Pixels))
+	for _, in := range circuit.In.Pixels {
+		channels = append(channels, in.RGB[:]...)
+	}
+
+	mapped := table.Lookup(channels...)
+
+	for i, out := range circuit.Out.Pixels {
+		for c := 0; c < 3; c++ {
+			api.AssertIsEqual(out.RGB[c], mapped[3*i+c])
+		}
+	}
+
+	return nil
+}
+
+func (circuit CurveCircuit) GetType() string {
+	return "curve_Fr_" + circuit.sizes()
+}
@@ -0,0 +1,60 @@
+package photoproof
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func gammaCurve(t *testing.T, gamma float64) LUT {
+	t.Helper()
+
+	lut, err := GammaCurve(gamma)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return lut
+}
+
+func TestCurve(t *testing.T) {
+	img := randomImage(t, testSize)
+
+	curve, err := NewCurve(img, gammaCurve(t, 2.2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, curve)
+
+	// The output of another curve, under the committed LUT
+	other, err := NewCurve(img, gammaCurve(t, 0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	curve.Result = other.Result
+	assertNotSolved(t, curve)
+}
+
+func TestCurveCommitment(t *testing.T) {
+	img := randomImage(t, testSize)
+
+	curve, err := NewCurve(img, gammaCurve(t, 2.2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The public commitment must be the commitment to the LUT actually applied
+	b, err := gammaCurve(t, 0.5).Commitment()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = solveWith(curve, func(assignment TransformationCircuit) {
+		var commitment fr.Element
+		commitment.SetBytes(b)
+		assignment.(*CurveCircuit).LUT_Commitment = commitment
+	})
+	if err == nil {
+		t.Fatal("curve edit with the commitment to another LUT should not be solved.")
+	}
+}
@@ -0,0 +1,246 @@
+package photoproof
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// An Edit is a permissible transformation of an authenticated image into a new image.
+// Its circuit proves that Output() was derived from Input() without revealing the pixels of either image:
+// only their sizes and commitments are public, so a chain of edit proofs links back to the original photo.
+type Edit interface {
+	Transformation
+	Input() image.Image
+	Output() image.Image
+}
+
+// Input and output images of an Edit. Embedded by every edit transformation.
+type EditImages struct {
+	Img    image.Image // Input image
+	Result image.Image // Output image
+}
+
+// Input and output images of an edit circuit. Every edit circuit embeds it as its first field, so the public witness
+// of every edit starts with the width, height and commitment of its input, followed by those of its output.
+// The parameters an edit circuit tags `gnark:"-"`, e.g. a crop's rectangle, are fixed when its PCD keys are generated,
+// so every value of them has its own circuit, told apart by GetType().
+type EditCircuit struct {
+	In  image.FrImage
+	Out image.FrImage
+}
+
+// Rectangle of pixels whose top-left pixel is at column X and row Y. Edit circuits are compiled for fixed rectangles.
+type Rect struct {
+	X, Y, Width, Height int
+}
+
+// In-circuit Rect, for edits whose rectangles are part of the witness.
+type FrRect struct {
+	X      frontend.Variable `gnark:",inherit"`
+	Y      frontend.Variable `gnark:",inherit"`
+	Width  frontend.Variable `gnark:",inherit"`
+	Height frontend.Variable `gnark:",inherit"`
+}
+
+// ---------------------------------------------------------------------------------------
+
+func (images EditImages) Input() image.Image {
+	return images.Img
+}
+
+func (images EditImages) Output() image.Image {
+	return images.Result
+}
+
+// Returns an unassigned EditCircuit for the given input and output sizes, used to compile circuits.
+func NewEditCircuit(in image.Size, out image.Size) EditCircuit {
+	return EditCircuit{In: image.NewFrImage(in), Out: image.NewFrImage(out)}
+}
+
+// Returns the assigned EditCircuit of an edit.
+func (images EditImages) ToFrEdit() EditCircuit {
+	return EditCircuit{In: images.Img.ToFr(), Out: images.Result.ToFr()}
+}
+
+// Constrains both images to open their public commitments. Every edit circuit calls it first in Define().
+func (circuit EditCircuit) AssertImages(api frontend.API) error {
+	err := circuit.In.AssertCommitment(api)
+	if err != nil {
+		return err
+	}
+
+	return circuit.Out.AssertCommitment(api)
+}
+
+// Returns the part of an edit circuit's type shared by every edit: its input and output sizes.
+func (circuit EditCircuit) sizes() string {
+	return circuit.In.Size.String() + "_to_" + circuit.Out.Size.String()
+}
+
+// Returns a short hexadecimal fingerprint of a circuit's compile-time parameters, for circuit types that cannot spell
+// them out: circuit types also name the key files written by SavePCDKeys(). See parameterizedCircuit.
+func fingerprint(params string) string {
+	h := sha256.Sum256([]byte(params))
+	return hex.EncodeToString(h[:8])
+}
+
+// Returns true if the pixel at (row, col) lies inside the rectangle.
+func (rect Rect) Contains(row int, col int) bool {
+	return col >= rect.X && col < rect.X+rect.Width && row >= rect.Y && row < rect.Y+rect.Height
+}
+
+// Returns an error unless the rectangle lies within an image of the given size. Empty rectangles are allowed.
+func (rect Rect) checkBounds(size image.Size) error {
+	if rect.Width < 0 || rect.Height < 0 || rect.X < 0 || rect.Y < 0 ||
+		rect.X+rect.Width > size.Width || rect.Y+rect.Height > size.Height {
+		return fmt.Errorf("ERROR: rectangle %dx%d+%d+%d does not fit in a %s image.", rect.Width, rect.Height, rect.X, rect.Y, size)
+	}
+
+	return nil
+}
+
+func (rect Rect) ToFr() FrRect {
+	return FrRect{X: rect.X, Y: rect.Y, Width: rect.Width, Height: rect.Height}
+}
+
+// Constrains the rectangle to lie within an image of the given size, as Rect.checkBounds() does.
+func (rect FrRect) assertBounds(api frontend.API, size image.Size) {
+	assertInRange(api, rect.X, size.Width)
+	assertInRange(api, rect.Width, size.Width)
+	assertInRange(api, api.Add(rect.X, rect.Width), size.Width)
+
+	assertInRange(api, rect.Y, size.Height)
+	assertInRange(api, rect.Height, size.Height)
+	assertInRange(api, api.Add(rect.Y, rect.Height), size.Height)
+}
+
+// Returns 1 if the pixel at (row, col) lies inside the rectangle and 0 otherwise, for a rectangle that passed
+// assertBounds() for the given size.
+func (rect FrRect) contains(api frontend.API, row int, col int, size image.Size) frontend.Variable {
+	// Coordinates and rectangle ends are at most the image's largest dimension
+	nbBits := nbBitsFor(max(size.Width, size.Height))
+
+	after_x := api.Sub(1, isLess(api, col, rect.X, nbBits))
+	before_end_x := isLess(api, col, api.Add(rect.X, rect.Width), nbBits)
+	after_y := api.Sub(1, isLess(api, row, rect.Y, nbBits))
+	before_end_y := isLess(api, row, api.Add(rect.Y, rect.Height), nbBits)
+
+	return api.And(api.And(after_x, before_end_x), api.And(after_y, before_end_y))
+}
+
+// ---------------------------------------------------------------------------------------
+
+// Proves that the edit's output was derived from its input, given PCD keys generated for the edit.
+// Can be used by any editor holding the input image; no camera secret key is needed.
+func Prove_Edit(edit Edit, PCD_Keys map[string]PCD_Keys) (Gnark_Proof, error) {
+	if err := edit.Input().Validate(); err != nil {
+		return Gnark_Proof{}, err
+	}
+	if err := edit.Output().Validate(); err != nil {
+		return Gnark_Proof{}, err
+	}
+
+	// Edits do not sign anything
+	circuit, err := edit.ToFr(nil, nil)
+	if err != nil {
+		return Gnark_Proof{}, fmt.Errorf("ERROR: edit.ToFr() while proving a %s edit: %w", edit.GetType(), err)
+	}
+
+	return prove(circuit, PCD_Keys)
+}
+
+// Returns the input and output images that an edit proof's public witness commits to.
+// The images only hold their size and commitment (PixelBytes); their pixels are never public.
+func EditStatement(proof Gnark_Proof) (in image.Image, out image.Image, err error) {
+	if proof.Public_Witness == nil {
+		return image.Image{}, image.Image{}, fmt.Errorf("ERROR: edit proof has no public witness.")
+	}
+
+	vector, ok := proof.Public_Witness.Vector().(fr.Vector)
+	if !ok || len(vector) < 6 {
+		return image.Image{}, image.Image{}, fmt.Errorf("ERROR: public witness is not the statement of an edit.")
+	}
+
+	header := func(v []fr.Element) image.Image {
+		b := v[2].Bytes()
+		return image.Image{Width: int(v[0].Uint64()), Height: int(v[1].Uint64()), PixelBytes: b[:]}
+	}
+
+	return header(vector[0:3]), header(vector[3:6]), nil
+}
+
+// Returns the public inputs of an edit proof that follow the statement returned by EditStatement(), in the order of
+// the public fields of the edit's circuit, e.g. the LUT_Commitment of a CurveCircuit.
+func EditParameters(proof Gnark_Proof) ([]fr.Element, error) {
+	if proof.Public_Witness == nil {
+		return nil, fmt.Errorf("ERROR: edit proof has no public witness.")
+	}
+
+	vector, ok := proof.Public_Witness.Vector().(fr.Vector)
+	if !ok || len(vector) < 6 {
+		return nil, fmt.Errorf("ERROR: public witness is not the statement of an edit.")
+	}
+
+	return append([]fr.Element{}, vector[6:]...), nil
+}
+
+// Returns true if both images have the same size and commitment.
+func SameCommitment(img1 image.Image, img2 image.Image) bool {
+	return img1.Size() == img2.Size() && bytes.Equal(img1.PixelBytes, img2.PixelBytes)
+}
+
+// ---------------------------------------------------------------------------------------
+
+// Returns a new image of the given size whose pixel at (row, col) has the channels rgb(row, col), with its commitment.
+// Used by edit transformations to compute their output natively.
+func newImageFrom(size image.Size, rgb func(row int, col int) [3]uint8) (image.Image, error) {
+	img, err := image.NewBlankImage(size)
+	if err != nil {
+		return image.Image{}, err
+	}
+
+	for idx, pxl := range img.Pixels {
+		img.Pixels[idx] = image.NewPixel(rgb(int(pxl.Loc.Row), int(pxl.Loc.Col)), pxl.Loc)
+	}
+
+	b, err := img.Commitment()
+	if err != nil {
+		return image.Image{}, err
+	}
+	img.PixelBytes = b
+
+	return img, nil
+}
+
+// Returns a copy of img with every pixel's channels replaced by f(pixel), with its commitment.
+func mapPixels(img image.Image, f func(pxl image.Pixel) [3]uint8) (image.Image, error) {
+	return newImageFrom(img.Size(), func(row int, col int) [3]uint8 {
+		return f(img.At(row, col))
+	})
+}
+
+// Returns the average color of the block x block square of img whose top-left pixel is at (top, left), rounded as
+// divRound does.
+func blockAverage(img image.Image, top int, left int, block int) [3]uint8 {
+	var sum [3]int
+	for row := top; row < top+block; row++ {
+		for col := left; col < left+block; col++ {
+			for c := 0; c < 3; c++ {
+				sum[c] += int(img.At(row, col).RGB[c])
+			}
+		}
+	}
+
+	var rgb [3]uint8
+	for c := 0; c < 3; c++ {
+		rgb[c] = uint8(divRoundInt(sum[c], block*block))
+	}
+
+	return rgb
+}
@@ -0,0 +1,106 @@
+package photoproof
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// Size of the images edit circuits are tested with; small, so the solver runs quickly.
+var testSize = image.Size{Width: 4, Height: 4}
+
+// Solves the edit's circuit, compiled for its input size, with the witness of the edit.
+// Only the constraints are checked; no PCD keys are generated.
+func solve(edit Edit) error {
+	return solveWith(edit, nil)
+}
+
+// Same as solve(), after tamper changes the witness, e.g. to bypass the checks of ToFr().
+func solveWith(edit Edit, tamper func(assignment TransformationCircuit)) error {
+	circuit, err := edit.NewCircuit(edit.Input().Size())
+	if err != nil {
+		return err
+	}
+
+	assignment, err := edit.ToFr(nil, nil)
+	if err != nil {
+		return err
+	}
+	if tamper != nil {
+		tamper(assignment)
+	}
+
+	return test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+}
+
+func assertSolved(t *testing.T, edit Edit) {
+	t.Helper()
+
+	if err := solve(edit); err != nil {
+		t.Fatalf("%s edit should be solved: %v", edit.GetType(), err)
+	}
+}
+
+func assertNotSolved(t *testing.T, edit Edit) {
+	t.Helper()
+
+	if err := solve(edit); err == nil {
+		t.Fatalf("%s edit should not be solved.", edit.GetType())
+	}
+}
+
+func randomImage(t *testing.T, size image.Size) image.Image {
+	t.Helper()
+
+	img, err := image.NewImage("random", size)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return img
+}
+
+// Returns an image of the given size whose pixel at (row, col) is rgb(row, col), with its commitment.
+func newTestImage(t *testing.T, size image.Size, rgb func(row int, col int) [3]uint8) image.Image {
+	t.Helper()
+
+	img, err := newImageFrom(size, rgb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return img
+}
+
+// Returns a copy of img whose pixels inside rect are rgb, with its commitment.
+func withRect(t *testing.T, img image.Image, rect Rect, rgb [3]uint8) image.Image {
+	t.Helper()
+
+	return newTestImage(t, img.Size(), func(row int, col int) [3]uint8 {
+		if rect.Contains(row, col) {
+			return rgb
+		}
+		return img.At(row, col).RGB
+	})
+}
+
+// Returns a copy of img whose pixel at (row, col) is rgb, with its commitment.
+func withPixel(t *testing.T, img image.Image, row int, col int, rgb [3]uint8) image.Image {
+	t.Helper()
+
+	return withRect(t, img, Rect{X: col, Y: row, Width: 1, Height: 1}, rgb)
+}
+
+func TestEditCommitment(t *testing.T) {
+	img := randomImage(t, testSize)
+	crop, err := NewCrop(img, 1, 1, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The output's pixels are correct, but its commitment is not theirs
+	crop.Result.PixelBytes = img.PixelBytes
+	assertNotSolved(t, crop)
+}
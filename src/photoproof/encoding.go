@@ -0,0 +1,141 @@
+package photoproof
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// Serializes the proof, its public witness and the fingerprint of its verifying key.
+// Keys are never serialized: viewers look the verifying key up in a trusted key store.
+func (proof Gnark_Proof) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := proof.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (proof *Gnark_Proof) UnmarshalBinary(data []byte) error {
+	_, err := proof.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// Writes the proof as a sequence of length-prefixed blobs: groth16 proof, public witness, VerifyingKey_ID.
+func (proof Gnark_Proof) WriteTo(w io.Writer) (int64, error) {
+	if proof.Gnark_Proof == nil || proof.Public_Witness == nil || len(proof.VerifyingKey_ID) == 0 {
+		return 0, fmt.Errorf("ERROR: cannot serialize an incomplete Gnark_Proof.")
+	}
+
+	var proof_bytes bytes.Buffer
+	if _, err := proof.Gnark_Proof.WriteTo(&proof_bytes); err != nil {
+		return 0, err
+	}
+	witness_bytes, err := proof.Public_Witness.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+
+	var n int64
+	for _, blob := range [][]byte{proof_bytes.Bytes(), witness_bytes, proof.VerifyingKey_ID} {
+		m, err := writeBlob(w, blob)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return n, nil
+}
+
+func (proof *Gnark_Proof) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+
+	blobs := make([][]byte, 3)
+	for i := range blobs {
+		blob, m, err := readBlob(r)
+		n += m
+		if err != nil {
+			return n, err
+		}
+		blobs[i] = blob
+	}
+
+	gnark_proof := groth16.NewProof(ecc.BN254)
+	if _, err := gnark_proof.ReadFrom(bytes.NewReader(blobs[0])); err != nil {
+		return n, fmt.Errorf("ERROR: reading groth16 proof: %w", err)
+	}
+
+	public_witness, err := witness.New(ecc.BN254.ScalarField())
+	if err != nil {
+		return n, err
+	}
+	if err := public_witness.UnmarshalBinary(blobs[1]); err != nil {
+		return n, fmt.Errorf("ERROR: reading public witness: %w", err)
+	}
+
+	*proof = Gnark_Proof{
+		Gnark_Proof:     gnark_proof,
+		Public_Witness:  public_witness,
+		VerifyingKey_ID: blobs[2],
+	}
+
+	return n, nil
+}
+
+// Returns the SHA-256 fingerprint of the verifying key's binary encoding.
+func VerifyingKeyID(vk groth16.VerifyingKey) ([]byte, error) {
+	if vk == nil {
+		return nil, fmt.Errorf("ERROR: no verifying key to fingerprint.")
+	}
+
+	h := sha256.New()
+	if _, err := vk.WriteTo(h); err != nil {
+		return nil, err
+	}
+
+	return h.Sum(nil), nil
+}
+
+// ----------------------------------------------------------------------------------------
+
+// Upper bound on a single serialized blob, so a corrupt length prefix cannot trigger a huge allocation.
+const maxBlobSize = 64 << 20
+
+func writeBlob(w io.Writer, blob []byte) (int64, error) {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(blob)))
+
+	n, err := w.Write(length[:])
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := w.Write(blob)
+
+	return int64(n + m), err
+}
+
+func readBlob(r io.Reader) ([]byte, int64, error) {
+	var length [4]byte
+	n, err := io.ReadFull(r, length[:])
+	if err != nil {
+		return nil, int64(n), err
+	}
+
+	size := binary.BigEndian.Uint32(length[:])
+	if size > maxBlobSize {
+		return nil, int64(n), fmt.Errorf("ERROR: serialized blob of %d bytes exceeds %d bytes.", size, maxBlobSize)
+	}
+
+	blob := make([]byte, size)
+	m, err := io.ReadFull(r, blob)
+
+	return blob, int64(n + m), err
+}
@@ -0,0 +1,78 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+type FlipMode string
+
+const (
+	FlipHorizontal FlipMode = "hflip" // Mirror left to right, e.g. selfie and mirror-mode captures
+	FlipVertical   FlipMode = "vflip" // Mirror top to bottom
+)
+
+// A Flip Transformation mirrors the image horizontally or vertically.
+// Like rotations, it is proven by a PermutationCircuit.
+type FlipTransformation struct {
+	Mode FlipMode
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewFlip(img image.Image, mode FlipMode) (FlipTransformation, error) {
+	flip := FlipTransformation{Mode: mode}
+
+	m, err := flip.pixelMap(img.Size())
+	if err != nil {
+		return FlipTransformation{}, err
+	}
+
+	flipped, err := m.Apply(img, img.Size())
+	if err != nil {
+		return FlipTransformation{}, err
+	}
+
+	flip.EditImages = EditImages{Img: img, Result: flipped}
+
+	return flip, err
+}
+
+func (flip FlipTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	m, err := flip.pixelMap(flip.Img.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	return &PermutationCircuit{EditCircuit: flip.ToFrEdit(), Name: string(flip.Mode), Map: m}, nil
+}
+
+func (flip FlipTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	m, err := flip.pixelMap(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PermutationCircuit{EditCircuit: NewEditCircuit(size, size), Name: string(flip.Mode), Map: m}, nil
+}
+
+func (flip FlipTransformation) GetType() string {
+	return "flip"
+}
+
+// Returns the flip's PixelMap, for an input image of the given size. The output has the same size.
+func (flip FlipTransformation) pixelMap(in image.Size) (PixelMap, error) {
+	switch flip.Mode {
+	case FlipHorizontal:
+		// out(r, c) = in(r, W-1-c)
+		return PixelMap{Row: [3]int{1, 0, 0}, Col: [3]int{0, -1, in.Width - 1}}, nil
+	case FlipVertical:
+		// out(r, c) = in(H-1-r, c)
+		return PixelMap{Row: [3]int{-1, 0, in.Height - 1}, Col: [3]int{0, 1, 0}}, nil
+	}
+
+	return PixelMap{}, fmt.Errorf("ERROR: unknown flip mode %q.", flip.Mode)
+}
@@ -0,0 +1,24 @@
+package photoproof
+
+import "testing"
+
+func TestFlip(t *testing.T) {
+	img := randomImage(t, testSize)
+
+	hflip, err := NewFlip(img, FlipHorizontal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, hflip)
+
+	vflip, err := NewFlip(img, FlipVertical)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, vflip)
+
+	// Each flip's output does not solve the other flip's circuit
+	hflip.Result, vflip.Result = vflip.Result, hflip.Result
+	assertNotSolved(t, hflip)
+	assertNotSolved(t, vflip)
+}
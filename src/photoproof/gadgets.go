@@ -0,0 +1,107 @@
+package photoproof
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+func init() {
+	// Hints are looked up by name when solving, so the prover needs them registered
+	solver.RegisterHint(divRoundHint)
+}
+
+// Small in-circuit building blocks shared by the edit circuits. They work on values known to be small
+// (e.g. channels, which AssertImages() range checks to 8 bits), which keeps them far cheaper than api.Cmp.
+
+// Returns the number of bits needed to represent every value in [0, max].
+func nbBitsFor(max int) int {
+	return bits.Len(uint(max))
+}
+
+// Returns 1 if a < b and 0 otherwise, for a and b known to lie in [0, 2^nbBits).
+func isLess(api frontend.API, a frontend.Variable, b frontend.Variable, nbBits int) frontend.Variable {
+	// a - b + 2^nbBits lies in [1, 2^(nbBits+1)), and its top bit is set if and only if a >= b
+	diff := api.ToBinary(api.Add(api.Sub(a, b), 1<<nbBits), nbBits+1)
+
+	return api.Sub(1, diff[nbBits])
+}
+
+// Constrains 0 <= v <= max.
+func assertInRange(api frontend.API, v frontend.Variable, max int) {
+	nbBits := nbBitsFor(max + 1)
+
+	// v fits in nbBits, then v < max + 1
+	api.ToBinary(v, nbBits)
+	api.AssertIsEqual(isLess(api, v, max+1, nbBits), 1)
+}
+
+// Returns min(max(v, lo), hi) for v known to lie in [lo - offset, hi + offset] with lo, offset >= 0.
+func clamp(api frontend.API, v frontend.Variable, lo int, hi int, offset int) frontend.Variable {
+	// Shift v so it is non-negative before comparing it
+	shifted := api.Add(v, offset)
+	nbBits := nbBitsFor(hi + 2*offset + 1)
+
+	below := isLess(api, shifted, lo+offset, nbBits)
+	above := isLess(api, hi+offset, shifted, nbBits)
+
+	return api.Select(below, lo, api.Select(above, hi, v))
+}
+
+// Returns v / d rounded to the nearest integer (halves rounded up), for v known to lie in [0, max] and a constant d > 0.
+func divRound(api frontend.API, v frontend.Variable, d int, max int) (frontend.Variable, error) {
+	// Division has no cheap constraint, so the quotient and remainder come from a hint and are then checked
+	res, err := api.Compiler().NewHint(divRoundHint, 2, v, d)
+	if err != nil {
+		return nil, err
+	}
+	q, r := res[0], res[1]
+
+	// q*d + r == v + d/2 with 0 <= r < d, and q small enough that q*d + r does not wrap around the field
+	api.AssertIsEqual(api.Add(api.Mul(q, d), r), api.Add(v, d/2))
+	assertInRange(api, r, d-1)
+	assertInRange(api, q, (max+d/2)/d)
+
+	return q, nil
+}
+
+// Hint for divRound: outputs the quotient and remainder of (v + d/2) / d, given v and d.
+func divRoundHint(field *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if len(inputs) != 2 || len(outputs) != 2 {
+		return fmt.Errorf("ERROR: divRoundHint expects 2 inputs and 2 outputs.")
+	}
+	if inputs[1].Sign() <= 0 {
+		return fmt.Errorf("ERROR: divRoundHint divides by %s.", inputs[1])
+	}
+
+	v := new(big.Int).Add(inputs[0], new(big.Int).Rsh(inputs[1], 1))
+	outputs[0].QuoRem(v, inputs[1], outputs[1])
+
+	return nil
+}
+
+// Returns channel c of the average color of the block x block square of img whose top-left pixel is at (top, left).
+// In-circuit counterpart of blockAverage.
+func frBlockAverage(api frontend.API, img image.FrImage, top int, left int, block int, c int) (frontend.Variable, error) {
+	n := block * block
+	width := img.Size.Width
+
+	var sum frontend.Variable = 0
+	for row := top; row < top+block; row++ {
+		for col := left; col < left+block; col++ {
+			sum = api.Add(sum, img.Pixels[row*width+col].RGB[c])
+		}
+	}
+
+	// sum lies in [0, 255*n] since channels are 8 bits
+	return divRound(api, sum, n, 255*n)
+}
+
+// Native counterpart of divRound, used to compute edit outputs.
+func divRoundInt(v int, d int) int {
+	return (v + d/2) / d
+}
@@ -1,14 +1,25 @@
 package photoproof
 
 import (
+	"crypto/sha256"
 	"fmt"
 
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+
 	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
 )
 
-// Generates PCD_Keys for each given Transformation.
+// Generates PCD_Keys for each given Transformation, for images of the given size.
 // Leverages interface Transformation to apply the same Generator function to various Transformations.
-func Generator(sk signature.Signer, trs []Transformation) (map[string]PCD_Keys, error) {
+func Generator(sk signature.Signer, trs []Transformation, size image.Size) (map[string]PCD_Keys, error) {
+	if err := size.Validate(); err != nil {
+		return nil, err
+	}
 
 	m := map[string]PCD_Keys{}
 
@@ -16,7 +27,7 @@ func Generator(sk signature.Signer, trs []Transformation) (map[string]PCD_Keys,
 
 		tr := trs[i]
 
-		FrTransformation, err := tr.ToFr(sk, sk.Public().Bytes())
+		FrTransformation, err := tr.NewCircuit(size)
 		if err != nil {
 			return nil, err
 		}
@@ -27,9 +38,67 @@ func Generator(sk signature.Signer, trs []Transformation) (map[string]PCD_Keys,
 			return map[string]PCD_Keys{}, fmt.Errorf("Generator() - ERROR while generating PCD_Keys; TrType: " + tr.GetType())
 		}
 
+		pcd_keys.Transformation = tr.GetType()
+		pcd_keys.Size = size
+		if circuit, ok := FrTransformation.(parameterizedCircuit); ok {
+			pcd_keys.Params = circuit.params()
+		}
+
 		// Set new M
 		m[FrTransformation.GetType()] = pcd_keys
 	}
 
 	return m, nil
 }
+
+// Compiles the circuit into a constraint system (aka compliance_predicate) and generates PCD_Keys from it.
+// Shared by the GeneratePCD_Keys() implementations of every TransformationCircuit.
+func generatePCD_Keys(circuit TransformationCircuit) (PCD_Keys, error) {
+
+	// Set the security parameter (BN254) and compile a constraint system (aka compliance_predicate)
+	compliance_predicate, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+	if err != nil {
+		fmt.Println("generatePCD_Keys(): ERROR while compiling constraint system for " + circuit.GetType())
+		return PCD_Keys{}, err
+	}
+
+	// Generate PCD Keys from the compliance_predicate
+	provingKey, verifyingKey, err := groth16.Setup(compliance_predicate)
+	if err != nil {
+		fmt.Println("generatePCD_Keys(): ERROR while generating PCD Keys from the constraint system for" + circuit.GetType())
+		return PCD_Keys{}, err
+	}
+
+	circuit_hash, err := constraintSystemHash(compliance_predicate)
+	if err != nil {
+		return PCD_Keys{}, err
+	}
+
+	pcd_keys := PCD_Keys{
+		ProvingKey:   provingKey,
+		VerifyingKey: verifyingKey,
+		Circuit_Hash: circuit_hash,
+	}
+
+	return pcd_keys, err
+}
+
+// Returns the SHA-256 fingerprint of the circuit's compiled constraint system.
+// PCD_Keys are only valid for the exact constraint system they were generated from.
+func CircuitHash(circuit TransformationCircuit) ([]byte, error) {
+	compliance_predicate, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+	if err != nil {
+		return nil, err
+	}
+
+	return constraintSystemHash(compliance_predicate)
+}
+
+func constraintSystemHash(compliance_predicate constraint.ConstraintSystem) ([]byte, error) {
+	h := sha256.New()
+	if _, err := compliance_predicate.WriteTo(h); err != nil {
+		return nil, err
+	}
+
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,50 @@
+package photoproof
+
+import (
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// Integer luma coefficients (ITU-R BT.601: 0.299, 0.587, 0.114) scaled by 256, so they sum to 256.
+const (
+	LumaR = 77
+	LumaG = 150
+	LumaB = 29
+)
+
+// A Grayscale Transformation replaces every pixel by its luma (Y, Y, Y), where
+// Y = (LumaR*R + LumaG*G + LumaB*B + 128) >> 8, i.e. the weighted sum divided by 256 and rounded half up.
+type GrayscaleTransformation struct {
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+// Returns the luma of a pixel, as computed by GrayscaleCircuit.
+func Luma(rgb [3]uint8) uint8 {
+	return uint8((LumaR*int(rgb[0]) + LumaG*int(rgb[1]) + LumaB*int(rgb[2]) + 128) >> 8)
+}
+
+func NewGrayscale(img image.Image) (GrayscaleTransformation, error) {
+	gray, err := mapPixels(img, func(pxl image.Pixel) [3]uint8 {
+		y := Luma(pxl.RGB)
+		return [3]uint8{y, y, y}
+	})
+	if err != nil {
+		return GrayscaleTransformation{}, err
+	}
+
+	return GrayscaleTransformation{EditImages: EditImages{Img: img, Result: gray}}, err
+}
+
+func (grayscale GrayscaleTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	return &GrayscaleCircuit{EditCircuit: grayscale.ToFrEdit()}, nil
+}
+
+func (grayscale GrayscaleTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	return &GrayscaleCircuit{EditCircuit: NewEditCircuit(size, size)}, nil
+}
+
+func (grayscale GrayscaleTransformation) GetType() string {
+	return "grayscale"
+}
@@ -0,0 +1,44 @@
+package photoproof
+
+import (
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Proves that every pixel of Out is (Y, Y, Y), where Y is the luma of the matching pixel of In (see Luma()).
+type GrayscaleCircuit struct {
+	EditCircuit
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit GrayscaleCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit GrayscaleCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	for i := range circuit.Out.Pixels {
+		in := circuit.In.Pixels[i]
+		out := circuit.Out.Pixels[i]
+
+		// The weighted sum plus 128 is at most 255*256 + 128 < 2^16, so dropping its low 8 bits divides it
+		// by 256 and rounds half up.
+		sum := api.Add(api.Mul(in.RGB[0], LumaR), api.Mul(in.RGB[1], LumaG), api.Mul(in.RGB[2], LumaB), 128)
+		sum_bits := api.ToBinary(sum, 16)
+		luma := api.FromBinary(sum_bits[8:]...)
+
+		for c := 0; c < 3; c++ {
+			api.AssertIsEqual(out.RGB[c], luma)
+		}
+	}
+
+	return nil
+}
+
+func (circuit GrayscaleCircuit) GetType() string {
+	return "grayscale_Fr_" + circuit.sizes()
+}
@@ -0,0 +1,34 @@
+package photoproof
+
+import "testing"
+
+func TestGrayscale(t *testing.T) {
+	// Red levels 0..15, some of which Luma() rounds up
+	img := newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+		return [3]uint8{uint8(row*testSize.Width + col), 0, 0}
+	})
+
+	grayscale, err := NewGrayscale(img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, grayscale)
+
+	// Luma truncated rather than rounded
+	truncated := grayscale
+	truncated.Result = newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+		y := uint8(LumaR * int(img.At(row, col).RGB[0]) >> 8)
+		return [3]uint8{y, y, y}
+	})
+	if SameCommitment(truncated.Result, grayscale.Result) {
+		t.Fatal("test image does not round any luma up.")
+	}
+	assertNotSolved(t, truncated)
+
+	// Luma in the red channel only
+	red := grayscale
+	red.Result = newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+		return [3]uint8{grayscale.Result.At(row, col).RGB[0], 0, 0}
+	})
+	assertNotSolved(t, red)
+}
@@ -49,12 +49,20 @@ func (idT IdentityTransformation) ToFr(sk signature.Signer, public_key []byte) (
 	circuit := &IdentityCircuit{
 		PublicKey:       eddsa_PK,
 		EdDSA_Signature: eddsa_digSig,
-		ImgBytes:        idT.Img.PixelBytes,
+		Img:             idT.Img.ToFr(),
 	}
 
 	return circuit, err
 }
 
+func (idT IdentityTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	if err := size.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &IdentityCircuit{Img: image.NewFrImage(size)}, nil
+}
+
 // TODO
 func (idT IdentityTransformation) VerifySignature(img image.Image) (bool, error) {
 	// Instantiate MIMC BN254 hash function, to be used in signing the image
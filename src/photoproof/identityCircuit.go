@@ -1,48 +1,24 @@
 package photoproof
 
 import (
-	"fmt"
-
-	"github.com/consensys/gnark-crypto/ecc"
 	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
 	"github.com/consensys/gnark-crypto/signature"
-	"github.com/consensys/gnark/backend/groth16"
 	"github.com/consensys/gnark/frontend"
-	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/consensys/gnark/std/algebra/native/twistededwards"
 	"github.com/consensys/gnark/std/hash/mimc"
 	"github.com/consensys/gnark/std/signature/eddsa"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
 )
 
 type IdentityCircuit struct {
-	PublicKey       eddsa.PublicKey
+	PublicKey       eddsa.PublicKey `gnark:",public"` // Camera's public key; binds the proof to the camera that signed the image
 	EdDSA_Signature eddsa.Signature
-	ImgBytes        frontend.Variable `gnark:",public"` // Image as a Big Endian bytes; used in signature verification
+	Img             image.FrImage // Secret pixels and their public commitment; the commitment is what the camera signs
 }
 
 // GeneratePCD_Keys implements TransformationCircuit.
 func (circuit IdentityCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
-
-	// Set the security parameter (BN254) and compile a constraint system (aka compliance_predicate)
-	compliance_predicate_id, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
-	if err != nil {
-		fmt.Println("generatePCD_Keys(): ERROR while compiling constraint system for " + circuit.GetType())
-		return PCD_Keys{}, err
-	}
-
-	// Generate PCD Keys from the compliance_predicate
-	provingKey, verifyingKey, err := groth16.Setup(compliance_predicate_id)
-	if err != nil {
-		fmt.Println("generatePCD_Keys(): ERROR while generating PCD Keys from the constraint system for" + circuit.GetType())
-		return PCD_Keys{}, err
-	}
-
-	pcd_keys := PCD_Keys{
-		ProvingKey:   provingKey,
-		VerifyingKey: verifyingKey,
-	}
-
-	return pcd_keys, err
+	return generatePCD_Keys(&circuit)
 }
 
 func (circuit IdentityCircuit) Define(api frontend.API) error {
@@ -58,20 +34,26 @@ func (circuit IdentityCircuit) Define(api frontend.API) error {
 		return err
 	}
 
+	// bind the secret pixels to the commitment that was signed
+	err = circuit.Img.AssertCommitment(api)
+	if err != nil {
+		return err
+	}
+
 	// tip: gnark profiles enable circuit developers to measure the number of constraints
 	// generated by a part of the (or the entire) circuit, using pprof.
 	// see github.com/consensys/gnark/profile
 
 	// verify the EdDSA signature
-	eddsa.Verify(curve, circuit.EdDSA_Signature, circuit.ImgBytes, circuit.PublicKey, &mimc)
+	eddsa.Verify(curve, circuit.EdDSA_Signature, circuit.Img.ImgBytes, circuit.PublicKey, &mimc)
 
 	// tip: api.Println behaves like go fmt.Println but accepts frontend.Variable
 	// that are resolved at Proving time
-	api.Println("message", circuit.ImgBytes)
+	api.Println("message", circuit.Img.ImgBytes)
 
 	return err
 }
 
 func (idCircuit IdentityCircuit) GetType() string {
-	return "id_Fr"
+	return "id_Fr_" + idCircuit.Img.Size.String()
 }
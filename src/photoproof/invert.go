@@ -0,0 +1,36 @@
+package photoproof
+
+import (
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// An Invert Transformation replaces every channel v of every pixel by 255 - v.
+type InvertTransformation struct {
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewInvert(img image.Image) (InvertTransformation, error) {
+	inverted, err := mapPixels(img, func(pxl image.Pixel) [3]uint8 {
+		return [3]uint8{255 - pxl.RGB[0], 255 - pxl.RGB[1], 255 - pxl.RGB[2]}
+	})
+	if err != nil {
+		return InvertTransformation{}, err
+	}
+
+	return InvertTransformation{EditImages: EditImages{Img: img, Result: inverted}}, err
+}
+
+func (invert InvertTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	return &InvertCircuit{EditCircuit: invert.ToFrEdit()}, nil
+}
+
+func (invert InvertTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	return &InvertCircuit{EditCircuit: NewEditCircuit(size, size)}, nil
+}
+
+func (invert InvertTransformation) GetType() string {
+	return "invert"
+}
@@ -0,0 +1,34 @@
+package photoproof
+
+import (
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Proves that every channel of Out is 255 minus the matching channel of In.
+type InvertCircuit struct {
+	EditCircuit
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit InvertCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit InvertCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	// Inverting every channel of a packed pixel is subtracting it from 0xFFFFFF, since no channel borrows
+	for i := range circuit.Out.Pixels {
+		api.AssertIsEqual(circuit.Out.Pixels[i].Packed, api.Sub(0xFFFFFF, circuit.In.Pixels[i].Packed))
+	}
+
+	return nil
+}
+
+func (circuit InvertCircuit) GetType() string {
+	return "invert_Fr_" + circuit.sizes()
+}
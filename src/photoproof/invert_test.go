@@ -0,0 +1,24 @@
+package photoproof
+
+import "testing"
+
+func TestInvert(t *testing.T) {
+	// Every channel takes both extreme values of 0..255
+	img := newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+		return [3]uint8{[4]uint8{0, 255, 1, 128}[col], [4]uint8{255, 0, 127, 254}[row], uint8(17 * (row + col))}
+	})
+
+	invert, err := NewInvert(img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, invert)
+
+	// Only the red channel is inverted
+	red := invert
+	red.Result = newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+		rgb := img.At(row, col).RGB
+		return [3]uint8{255 - rgb[0], rgb[1], rgb[2]}
+	})
+	assertNotSolved(t, red)
+}
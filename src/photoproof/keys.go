@@ -0,0 +1,224 @@
+package photoproof
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// Name of the manifest file written by SavePCDKeys() next to the key files.
+const ManifestFile = "manifest.json"
+
+// Version of the manifest format written by SavePCDKeys().
+const ManifestVersion = 1
+
+// Describes a directory of persisted PCD_Keys.
+type PCD_Manifest struct {
+	Version int                 `json:"version"`
+	Keys    []PCD_ManifestEntry `json:"keys"`
+}
+
+// Describes one set of persisted PCD_Keys, stored in a proving key file and a verifying key file.
+type PCD_ManifestEntry struct {
+	Type            string `json:"type"`             // Circuit type, i.e. the key in the map of PCD_Keys
+	Transformation  string `json:"transformation"`   // Transformation type
+	Curve           string `json:"curve"`            // Curve of the groth16 keys
+	Width           int    `json:"width"`            // Width of the input images
+	Height          int    `json:"height"`           // Height of the input images
+	Circuit_Hash    string `json:"circuit_hash"`     // Hex fingerprint of the compiled constraint system
+	VerifyingKey_ID string `json:"verifying_key_id"` // Hex fingerprint of the verifying key
+	Params          string `json:"params,omitempty"` // Compile-time parameters that the circuit type only fingerprints
+	ProvingKey      string `json:"proving_key"`      // File name, relative to the manifest
+	VerifyingKey    string `json:"verifying_key"`    // File name, relative to the manifest
+}
+
+// ---------------------------------------------------------------------------------------
+
+// Writes every set of PCD_Keys to dir (one proving key file and one verifying key file each) along with a manifest,
+// so that groth16.Setup runs once per deployment instead of once per run.
+func SavePCDKeys(dir string, m map[string]PCD_Keys) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	manifest := PCD_Manifest{Version: ManifestVersion}
+
+	// Sort the types so the manifest is deterministic
+	types := make([]string, 0, len(m))
+	for trType := range m {
+		types = append(types, trType)
+	}
+	sort.Strings(types)
+
+	for _, trType := range types {
+		keys := m[trType]
+		if keys.ProvingKey == nil || keys.VerifyingKey == nil {
+			return fmt.Errorf("ERROR: PCD_Keys for %s are incomplete.", trType)
+		}
+
+		vk_id, err := VerifyingKeyID(keys.VerifyingKey)
+		if err != nil {
+			return err
+		}
+
+		entry := PCD_ManifestEntry{
+			Type:            trType,
+			Transformation:  keys.Transformation,
+			Curve:           ecc.BN254.String(),
+			Width:           keys.Size.Width,
+			Height:          keys.Size.Height,
+			Circuit_Hash:    hex.EncodeToString(keys.Circuit_Hash),
+			VerifyingKey_ID: hex.EncodeToString(vk_id),
+			Params:          keys.Params,
+			ProvingKey:      trType + ".pk",
+			VerifyingKey:    trType + ".vk",
+		}
+
+		if err := writeKeyFile(filepath.Join(dir, entry.ProvingKey), keys.ProvingKey); err != nil {
+			return err
+		}
+		if err := writeKeyFile(filepath.Join(dir, entry.VerifyingKey), keys.VerifyingKey); err != nil {
+			return err
+		}
+
+		manifest.Keys = append(manifest.Keys, entry)
+	}
+
+	manifest_bytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, ManifestFile), manifest_bytes, 0o644)
+}
+
+// Reads the PCD_Keys written by SavePCDKeys(). Keys on another curve, or whose verifying key does not match
+// the fingerprint recorded in the manifest, are rejected.
+func LoadPCDKeys(dir string) (map[string]PCD_Keys, error) {
+	return loadPCDKeys(dir, true)
+}
+
+// Same as LoadPCDKeys(), but skips the (large) proving keys, which viewers never need.
+func LoadVerifyingKeys(dir string) (map[string]PCD_Keys, error) {
+	return loadPCDKeys(dir, false)
+}
+
+func loadPCDKeys(dir string, withProvingKeys bool) (map[string]PCD_Keys, error) {
+	manifest_bytes, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest PCD_Manifest
+	if err := json.Unmarshal(manifest_bytes, &manifest); err != nil {
+		return nil, fmt.Errorf("ERROR: reading PCD_Keys manifest: %w", err)
+	}
+	if manifest.Version != ManifestVersion {
+		return nil, fmt.Errorf("ERROR: unsupported PCD_Keys manifest version %d.", manifest.Version)
+	}
+
+	m := map[string]PCD_Keys{}
+
+	for _, entry := range manifest.Keys {
+		if entry.Curve != ecc.BN254.String() {
+			return nil, fmt.Errorf("ERROR: PCD_Keys for %s are on curve %s, expected %s.", entry.Type, entry.Curve, ecc.BN254)
+		}
+
+		circuit_hash, err := hex.DecodeString(entry.Circuit_Hash)
+		if err != nil {
+			return nil, fmt.Errorf("ERROR: bad circuit hash for %s: %w", entry.Type, err)
+		}
+
+		var provingKey groth16.ProvingKey
+		if withProvingKeys {
+			provingKey = groth16.NewProvingKey(ecc.BN254)
+			if err := readKeyFile(filepath.Join(dir, filepath.Base(entry.ProvingKey)), provingKey); err != nil {
+				return nil, err
+			}
+		}
+
+		verifyingKey := groth16.NewVerifyingKey(ecc.BN254)
+		if err := readKeyFile(filepath.Join(dir, filepath.Base(entry.VerifyingKey)), verifyingKey); err != nil {
+			return nil, err
+		}
+
+		vk_id, err := VerifyingKeyID(verifyingKey)
+		if err != nil {
+			return nil, err
+		}
+		if hex.EncodeToString(vk_id) != entry.VerifyingKey_ID {
+			return nil, fmt.Errorf("ERROR: verifying key of %s does not match its manifest entry.", entry.Type)
+		}
+
+		m[entry.Type] = PCD_Keys{
+			ProvingKey:     provingKey,
+			VerifyingKey:   verifyingKey,
+			Transformation: entry.Transformation,
+			Size:           image.Size{Width: entry.Width, Height: entry.Height},
+			Circuit_Hash:   circuit_hash,
+			Params:         entry.Params,
+		}
+	}
+
+	return m, nil
+}
+
+// Returns an error unless the keys were generated from the circuit's current constraint system.
+// Use it after LoadPCDKeys(), since persisted keys silently break whenever a circuit changes.
+func (keys PCD_Keys) CheckCircuit(circuit TransformationCircuit) error {
+	circuit_hash, err := CircuitHash(circuit)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(circuit_hash, keys.Circuit_Hash) {
+		return fmt.Errorf("ERROR: PCD_Keys were generated for another version of circuit %s.", circuit.GetType())
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------
+
+func writeKeyFile(path string, key io.WriterTo) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	if _, err := key.WriteTo(w); err != nil {
+		f.Close()
+		return fmt.Errorf("ERROR: writing %s: %w", path, err)
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func readKeyFile(path string, key io.ReaderFrom) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := key.ReadFrom(bufio.NewReader(f)); err != nil {
+		return fmt.Errorf("ERROR: reading %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package photoproof
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// Transformations whose circuits are small enough for their keys to be generated quickly; the palette circuit
+// has compile-time parameters.
+var keyTransformations = []Transformation{
+	InvertTransformation{},
+	GrayscaleTransformation{},
+	PaletteTransformation{Palette: [][3]uint8{{0, 0, 0}, {255, 255, 255}}},
+}
+
+// Keys of keyTransformations, generated once for every test.
+var generatedKeys = sync.OnceValues(func() (map[string]PCD_Keys, error) {
+	return Generator(nil, keyTransformations, testSize)
+})
+
+// Saves the keys of keyTransformations to a new directory, which is returned along with the keys.
+func savedKeys(t *testing.T) (map[string]PCD_Keys, string) {
+	t.Helper()
+
+	m, err := generatedKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := SavePCDKeys(dir, m); err != nil {
+		t.Fatal(err)
+	}
+
+	return m, dir
+}
+
+func TestPCDKeysRoundTrip(t *testing.T) {
+	m, dir := savedKeys(t)
+
+	loaded, err := LoadPCDKeys(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != len(m) {
+		t.Fatalf("loaded %d PCD_Keys, expected %d.", len(loaded), len(m))
+	}
+
+	for _, tr := range keyTransformations {
+		circuit, err := tr.NewCircuit(testSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		keys, ok := loaded[circuit.GetType()]
+		if !ok {
+			t.Fatalf("no PCD_Keys loaded for %s.", circuit.GetType())
+		}
+		if keys.ProvingKey == nil || keys.Transformation != tr.GetType() || keys.Size != testSize || keys.Params != m[circuit.GetType()].Params {
+			t.Fatalf("PCD_Keys for %s do not describe the keys that were saved.", circuit.GetType())
+		}
+
+		want, err := VerifyingKeyID(m[circuit.GetType()].VerifyingKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := VerifyingKeyID(keys.VerifyingKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(want, got) {
+			t.Fatalf("loaded verifying key for %s is not the saved one.", circuit.GetType())
+		}
+
+		if err := keys.CheckCircuit(circuit); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Keys only match the circuit they were generated from
+	invert, err := InvertTransformation{}.NewCircuit(testSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grayscale, err := GrayscaleTransformation{}.NewCircuit(testSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded[grayscale.GetType()].CheckCircuit(invert); err == nil {
+		t.Fatal("grayscale keys should not match the invert circuit.")
+	}
+
+	verifying, err := LoadVerifyingKeys(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verifying[invert.GetType()].ProvingKey != nil {
+		t.Fatal("LoadVerifyingKeys() should not load proving keys.")
+	}
+}
+
+func TestPCDKeysTamperedManifest(t *testing.T) {
+	_, dir := savedKeys(t)
+
+	path := filepath.Join(dir, ManifestFile)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var manifest PCD_Manifest
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	// Record the fingerprint of another entry's verifying key
+	manifest.Keys[0].VerifyingKey_ID = manifest.Keys[1].VerifyingKey_ID
+	b, err = json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadPCDKeys(dir); err == nil {
+		t.Fatal("PCD_Keys with a tampered manifest should not be loaded.")
+	}
+}
+
+func TestPCDKeysSwappedVerifyingKey(t *testing.T) {
+	_, dir := savedKeys(t)
+
+	invert, err := InvertTransformation{}.NewCircuit(testSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grayscale, err := GrayscaleTransformation{}.NewCircuit(testSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Replace the grayscale verifying key by the invert one, which is a valid key of its own
+	b, err := os.ReadFile(filepath.Join(dir, invert.GetType()+".vk"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, grayscale.GetType()+".vk"), b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadVerifyingKeys(dir); err == nil {
+		t.Fatal("PCD_Keys with a swapped verifying key should not be loaded.")
+	}
+}
@@ -0,0 +1,91 @@
+package photoproof
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// A Palette Transformation replaces every pixel by the Palette color nearest to it, by squared Euclidean distance in
+// RGB. Ties go to the first nearest color, but the circuit accepts any of them.
+type PaletteTransformation struct {
+	Palette [][3]uint8
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+// Returns the squared Euclidean distance between two colors, as computed by PaletteCircuit.
+func colorDistance(a [3]uint8, b [3]uint8) int {
+	dist := 0
+	for c := 0; c < 3; c++ {
+		d := int(a[c]) - int(b[c])
+		dist += d * d
+	}
+
+	return dist
+}
+
+func NewPalette(img image.Image, palette [][3]uint8) (PaletteTransformation, error) {
+	p := PaletteTransformation{Palette: palette}
+	if err := p.checkPalette(); err != nil {
+		return PaletteTransformation{}, err
+	}
+
+	mapped, err := mapPixels(img, func(pxl image.Pixel) [3]uint8 {
+		nearest := palette[0]
+		for _, color := range palette[1:] {
+			if colorDistance(pxl.RGB, color) < colorDistance(pxl.RGB, nearest) {
+				nearest = color
+			}
+		}
+		return nearest
+	})
+	if err != nil {
+		return PaletteTransformation{}, err
+	}
+
+	p.EditImages = EditImages{Img: img, Result: mapped}
+
+	return p, err
+}
+
+func (p PaletteTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	if err := p.checkPalette(); err != nil {
+		return nil, err
+	}
+
+	return &PaletteCircuit{EditCircuit: p.ToFrEdit(), Palette: p.Palette}, nil
+}
+
+func (p PaletteTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	if err := p.checkPalette(); err != nil {
+		return nil, err
+	}
+
+	return &PaletteCircuit{EditCircuit: NewEditCircuit(size, size), Palette: p.Palette}, nil
+}
+
+func (p PaletteTransformation) GetType() string {
+	return "palette"
+}
+
+func (p PaletteTransformation) checkPalette() error {
+	if len(p.Palette) == 0 {
+		return fmt.Errorf("ERROR: empty palette.")
+	}
+
+	return nil
+}
+
+// Returns the palette's colors in hexadecimal, e.g. "000000-ffffff", recorded with the PCD_Keys of the palette's circuit.
+func paletteString(palette [][3]uint8) string {
+	colors := make([]string, len(palette))
+	for i, color := range palette {
+		colors[i] = fmt.Sprintf("%02x%02x%02x", color[0], color[1], color[2])
+	}
+
+	return strings.Join(colors, "-")
+}
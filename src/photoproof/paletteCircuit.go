@@ -0,0 +1,69 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// Proves that every pixel of Out is a Palette color, and that no Palette color is nearer to the matching pixel of In.
+type PaletteCircuit struct {
+	EditCircuit
+	Palette [][3]uint8 `gnark:"-"`
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit PaletteCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit PaletteCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	// Squared distances lie in [0, 3*255*255]
+	nbBits := nbBitsFor(3 * 255 * 255)
+
+	for i := range circuit.Out.Pixels {
+		in := circuit.In.Pixels[i]
+		out := circuit.Out.Pixels[i]
+
+		// out is a Palette color: the product of its differences with every color is 0
+		var membership frontend.Variable = 1
+		for _, color := range circuit.Palette {
+			membership = api.Mul(membership, api.Sub(out.Packed, image.Pack(color)))
+		}
+		api.AssertIsEqual(membership, 0)
+
+		distance := func(rgb [3]frontend.Variable) frontend.Variable {
+			var dist frontend.Variable = 0
+			for c := 0; c < 3; c++ {
+				d := api.Sub(in.RGB[c], rgb[c])
+				dist = api.Add(dist, api.Mul(d, d))
+			}
+			return dist
+		}
+
+		// No Palette color is strictly nearer than out
+		out_dist := distance(out.RGB)
+		for _, color := range circuit.Palette {
+			color_dist := distance([3]frontend.Variable{color[0], color[1], color[2]})
+			api.AssertIsEqual(isLess(api, color_dist, out_dist, nbBits), 0)
+		}
+	}
+
+	return nil
+}
+
+func (circuit PaletteCircuit) GetType() string {
+	return fmt.Sprintf("palette_Fr_%s_%dcolors_%s", circuit.sizes(), len(circuit.Palette), fingerprint(circuit.params()))
+}
+
+// Implements parameterizedCircuit: the colors do not fit in the circuit type.
+func (circuit PaletteCircuit) params() string {
+	return paletteString(circuit.Palette)
+}
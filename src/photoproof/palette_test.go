@@ -0,0 +1,56 @@
+package photoproof
+
+import "testing"
+
+func TestPaletteTies(t *testing.T) {
+	// (1, 0, 0) is as near to black as to (2, 0, 0), and far from white
+	img := withPixel(t, randomImage(t, testSize), 0, 0, [3]uint8{1, 0, 0})
+	palette := [][3]uint8{{0, 0, 0}, {2, 0, 0}, {255, 255, 255}}
+
+	p, err := NewPalette(img, palette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, p)
+
+	// Either of the nearest colors is accepted
+	tie := p
+	tie.Result = withPixel(t, p.Result, 0, 0, [3]uint8{2, 0, 0})
+	assertSolved(t, tie)
+
+	// White is in the palette, but further away
+	far := p
+	far.Result = withPixel(t, p.Result, 0, 0, [3]uint8{255, 255, 255})
+	assertNotSolved(t, far)
+
+	// The pixel itself is nearer, but not in the palette
+	outside := p
+	outside.Result = withPixel(t, p.Result, 0, 0, [3]uint8{1, 0, 0})
+	assertNotSolved(t, outside)
+}
+
+func TestPaletteType(t *testing.T) {
+	// Types name key files, so they must stay short however many colors the palette has
+	palette := make([][3]uint8, 256)
+	for i := range palette {
+		palette[i] = [3]uint8{uint8(i), uint8(i), uint8(i)}
+	}
+
+	circuit, err := PaletteTransformation{Palette: palette}.NewCircuit(testSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(circuit.GetType()) > 64 {
+		t.Fatalf("circuit type %q is too long.", circuit.GetType())
+	}
+
+	// Palettes with the same number of colors still have their own circuit
+	changed := append([][3]uint8{{255, 0, 0}}, palette[1:]...)
+	other, err := PaletteTransformation{Palette: changed}.NewCircuit(testSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if circuit.GetType() == other.GetType() {
+		t.Fatalf("different palettes share the circuit type %q.", circuit.GetType())
+	}
+}
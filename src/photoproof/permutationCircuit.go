@@ -0,0 +1,99 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// Affine map from the location of an output pixel to the location of the input pixel it is copied from:
+//
+//	in.Row = Row[0]*out.Row + Row[1]*out.Col + Row[2]
+//	in.Col = Col[0]*out.Row + Col[1]*out.Col + Col[2]
+//
+// Rotations by multiples of 90 degrees, transposition and flips are all such maps.
+type PixelMap struct {
+	Row [3]int
+	Col [3]int
+}
+
+// Proves that Out is a permutation of In's pixels given by Map: every output pixel equals the input pixel at the
+// mapped location. Shared by the rotation and flip transformations, which only differ by their Map.
+type PermutationCircuit struct {
+	EditCircuit
+	Name string   `gnark:"-"` // Name of the permutation, e.g. "rotate90"
+	Map  PixelMap `gnark:"-"`
+}
+
+// ---------------------------------------------------------------------------------------
+
+// Returns the location of the input pixel that the output pixel at (row, col) is copied from.
+func (m PixelMap) Source(row int, col int) (int, int) {
+	return m.Row[0]*row + m.Row[1]*col + m.Row[2], m.Col[0]*row + m.Col[1]*col + m.Col[2]
+}
+
+// Returns a new image of size out whose pixels are copied from img according to m.
+func (m PixelMap) Apply(img image.Image, out image.Size) (image.Image, error) {
+	if err := m.check(img.Size(), out); err != nil {
+		return image.Image{}, err
+	}
+
+	return newImageFrom(out, func(row int, col int) [3]uint8 {
+		return img.At(m.Source(row, col)).RGB
+	})
+}
+
+// Returns an error unless every output pixel is mapped to a pixel inside the input.
+func (m PixelMap) check(in image.Size, out image.Size) error {
+	for row := 0; row < out.Height; row++ {
+		for col := 0; col < out.Width; col++ {
+			src_row, src_col := m.Source(row, col)
+			if src_row < 0 || src_row >= in.Height || src_col < 0 || src_col >= in.Width {
+				return fmt.Errorf("ERROR: pixel map sends (%d, %d) outside of a %s image.", row, col, in)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit PermutationCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit PermutationCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	err = circuit.Map.check(circuit.In.Size, circuit.Out.Size)
+	if err != nil {
+		return err
+	}
+
+	for i := range circuit.Out.Pixels {
+		out := circuit.Out.Pixels[i]
+
+		row, col := i/circuit.Out.Size.Width, i%circuit.Out.Size.Width
+		src_row, src_col := circuit.Map.Source(row, col)
+		in := circuit.In.Pixels[src_row*circuit.In.Size.Width+src_col]
+
+		// The input pixel's location is the remapped location of the output pixel
+		api.AssertIsEqual(in.Loc.Row, api.Add(api.Mul(out.Loc.Row, circuit.Map.Row[0]), api.Mul(out.Loc.Col, circuit.Map.Row[1]), circuit.Map.Row[2]))
+		api.AssertIsEqual(in.Loc.Col, api.Add(api.Mul(out.Loc.Row, circuit.Map.Col[0]), api.Mul(out.Loc.Col, circuit.Map.Col[1]), circuit.Map.Col[2]))
+
+		api.AssertIsEqual(out.Packed, in.Packed)
+	}
+
+	return nil
+}
+
+func (circuit PermutationCircuit) GetType() string {
+	return circuit.Name + "_Fr_" + circuit.sizes()
+}
@@ -0,0 +1,83 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// A Perturbation Transformation allows any edit that changes every channel of every pixel by at most Epsilon,
+// whatever produced it, e.g. small touch-ups or the noise of lossy re-encoding.
+// Epsilon only bounds a single edit: k chained perturbations reach k*Epsilon, so viewers accept at most one
+// perturbation or brightness edit per photograph (see viewer.VerifyEdits).
+type PerturbationTransformation struct {
+	Epsilon int
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+// Returns the perturbation of img into result, which must have the same size and be within Epsilon of img.
+func NewPerturbation(img image.Image, result image.Image, epsilon int) (PerturbationTransformation, error) {
+	perturbation := PerturbationTransformation{Epsilon: epsilon}
+	if err := perturbation.checkEpsilon(); err != nil {
+		return PerturbationTransformation{}, err
+	}
+
+	if err := result.Validate(); err != nil {
+		return PerturbationTransformation{}, err
+	}
+	if result.Size() != img.Size() {
+		return PerturbationTransformation{}, fmt.Errorf("ERROR: perturbed image is %s, expected %s.", result.Size(), img.Size())
+	}
+
+	for i, pxl := range img.Pixels {
+		for c := 0; c < 3; c++ {
+			diff := int(result.Pixels[i].RGB[c]) - int(pxl.RGB[c])
+			if diff < -epsilon || diff > epsilon {
+				return PerturbationTransformation{}, fmt.Errorf("ERROR: channel %d of pixel %d changed by %d, more than ±%d.", c, i, diff, epsilon)
+			}
+		}
+	}
+
+	// The result's commitment is recomputed rather than trusted
+	perturbed, err := mapPixels(result, func(pxl image.Pixel) [3]uint8 {
+		return pxl.RGB
+	})
+	if err != nil {
+		return PerturbationTransformation{}, err
+	}
+
+	perturbation.EditImages = EditImages{Img: img, Result: perturbed}
+
+	return perturbation, err
+}
+
+func (perturbation PerturbationTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	if err := perturbation.checkEpsilon(); err != nil {
+		return nil, err
+	}
+
+	return &PerturbationCircuit{EditCircuit: perturbation.ToFrEdit(), Epsilon: perturbation.Epsilon}, nil
+}
+
+func (perturbation PerturbationTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	if err := perturbation.checkEpsilon(); err != nil {
+		return nil, err
+	}
+
+	return &PerturbationCircuit{EditCircuit: NewEditCircuit(size, size), Epsilon: perturbation.Epsilon}, nil
+}
+
+func (perturbation PerturbationTransformation) GetType() string {
+	return "perturbation"
+}
+
+func (perturbation PerturbationTransformation) checkEpsilon() error {
+	if perturbation.Epsilon < 0 || perturbation.Epsilon > 255 {
+		return fmt.Errorf("ERROR: perturbation bound %d is not within 0..255.", perturbation.Epsilon)
+	}
+
+	return nil
+}
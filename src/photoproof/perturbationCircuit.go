@@ -0,0 +1,42 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Proves that every channel of Out lies within ±Epsilon of the matching channel of In.
+type PerturbationCircuit struct {
+	EditCircuit
+	Epsilon int `gnark:"-"`
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit PerturbationCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit PerturbationCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	for i := range circuit.Out.Pixels {
+		in := circuit.In.Pixels[i]
+		out := circuit.Out.Pixels[i]
+
+		for c := 0; c < 3; c++ {
+			// -Epsilon <= out - in <= Epsilon
+			assertInRange(api, api.Add(api.Sub(out.RGB[c], in.RGB[c]), circuit.Epsilon), 2*circuit.Epsilon)
+		}
+	}
+
+	return nil
+}
+
+func (circuit PerturbationCircuit) GetType() string {
+	return fmt.Sprintf("perturbation_Fr_%s_eps%d", circuit.sizes(), circuit.Epsilon)
+}
@@ -0,0 +1,40 @@
+package photoproof
+
+import "testing"
+
+func TestPerturbationBound(t *testing.T) {
+	img := clampImage(t)
+
+	// Every channel moves by exactly 2, away from the nearest end of 0..255
+	moved := newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+		rgb := img.At(row, col).RGB
+		for c := range rgb {
+			if rgb[c] < 128 {
+				rgb[c] += 2
+			} else {
+				rgb[c] -= 2
+			}
+		}
+		return rgb
+	})
+
+	perturbation, err := NewPerturbation(img, moved, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, perturbation)
+
+	// The same output under a circuit compiled for a bound of 1
+	perturbation.Epsilon = 1
+	assertNotSolved(t, perturbation)
+}
+
+func TestPerturbationNone(t *testing.T) {
+	img := randomImage(t, testSize)
+
+	perturbation, err := NewPerturbation(img, img, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, perturbation)
+}
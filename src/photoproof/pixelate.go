@@ -0,0 +1,77 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// A Pixelate Transformation replaces every Block x Block square of the Region by its average color, rounded to the
+// nearest integer (halves rounded up). Pixels outside the Region are unchanged.
+type PixelateTransformation struct {
+	Region Rect // Width and Height must be multiples of Block
+	Block  int
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewPixelate(img image.Image, region Rect, block int) (PixelateTransformation, error) {
+	pixelate := PixelateTransformation{Region: region, Block: block}
+	if err := pixelate.checkBounds(img.Size()); err != nil {
+		return PixelateTransformation{}, err
+	}
+
+	pixelated, err := newImageFrom(img.Size(), func(row int, col int) [3]uint8 {
+		if !region.Contains(row, col) {
+			return img.At(row, col).RGB
+		}
+
+		// Top-left pixel of the block containing (row, col)
+		top := region.Y + (row-region.Y)/block*block
+		left := region.X + (col-region.X)/block*block
+
+		return blockAverage(img, top, left, block)
+	})
+	if err != nil {
+		return PixelateTransformation{}, err
+	}
+
+	pixelate.EditImages = EditImages{Img: img, Result: pixelated}
+
+	return pixelate, err
+}
+
+func (pixelate PixelateTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	if err := pixelate.checkBounds(pixelate.Img.Size()); err != nil {
+		return nil, err
+	}
+
+	return &PixelateCircuit{EditCircuit: pixelate.ToFrEdit(), Region: pixelate.Region, Block: pixelate.Block}, nil
+}
+
+func (pixelate PixelateTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	if err := pixelate.checkBounds(size); err != nil {
+		return nil, err
+	}
+
+	return &PixelateCircuit{EditCircuit: NewEditCircuit(size, size), Region: pixelate.Region, Block: pixelate.Block}, nil
+}
+
+func (pixelate PixelateTransformation) GetType() string {
+	return "pixelate"
+}
+
+// Returns an error unless the region lies within an image of the given size and is tiled by whole blocks.
+func (pixelate PixelateTransformation) checkBounds(size image.Size) error {
+	if err := pixelate.Region.checkBounds(size); err != nil {
+		return err
+	}
+	if pixelate.Block <= 0 || pixelate.Region.Width%pixelate.Block != 0 || pixelate.Region.Height%pixelate.Block != 0 {
+		return fmt.Errorf("ERROR: %dx%d region is not tiled by %dx%d blocks.",
+			pixelate.Region.Width, pixelate.Region.Height, pixelate.Block, pixelate.Block)
+	}
+
+	return nil
+}
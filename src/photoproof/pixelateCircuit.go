@@ -0,0 +1,61 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Proves that every Block x Block square of Out inside Region is the rounded average of the same square of In,
+// and that every other pixel of Out equals the pixel of In.
+type PixelateCircuit struct {
+	EditCircuit
+	Region Rect `gnark:"-"`
+	Block  int  `gnark:"-"`
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit PixelateCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit PixelateCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	width := circuit.In.Size.Width
+	block := circuit.Block
+
+	for i := range circuit.Out.Pixels {
+		if !circuit.Region.Contains(i/width, i%width) {
+			api.AssertIsEqual(circuit.Out.Pixels[i].Packed, circuit.In.Pixels[i].Packed)
+		}
+	}
+
+	for top := circuit.Region.Y; top < circuit.Region.Y+circuit.Region.Height; top += block {
+		for left := circuit.Region.X; left < circuit.Region.X+circuit.Region.Width; left += block {
+			for c := 0; c < 3; c++ {
+				average, err := frBlockAverage(api, circuit.In, top, left, block, c)
+				if err != nil {
+					return err
+				}
+
+				for row := top; row < top+block; row++ {
+					for col := left; col < left+block; col++ {
+						api.AssertIsEqual(circuit.Out.Pixels[row*width+col].RGB[c], average)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (circuit PixelateCircuit) GetType() string {
+	rect := circuit.Region
+	return fmt.Sprintf("pixelate_Fr_%s_%dx%d+%d+%d_block%d", circuit.sizes(), rect.Width, rect.Height, rect.X, rect.Y, circuit.Block)
+}
@@ -0,0 +1,54 @@
+package photoproof
+
+import (
+	"testing"
+
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// Returns a black image but for the pixel at (1, 1), so that the average of its top-left 2x2 block is (2, 1, 3)/4:
+// a half, a quarter and three quarters.
+func halvesImage(t *testing.T) image.Image {
+	t.Helper()
+
+	return withPixel(t, newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+		return [3]uint8{}
+	}), 1, 1, [3]uint8{2, 1, 3})
+}
+
+func TestPixelateRounding(t *testing.T) {
+	img := halvesImage(t)
+	block := Rect{X: 0, Y: 0, Width: 2, Height: 2}
+
+	pixelate, err := NewPixelate(img, Rect{X: 0, Y: 0, Width: 2, Height: 4}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, pixelate)
+
+	// Halves round up, so the block is (1, 0, 1)
+	if got := pixelate.Result.At(0, 0).RGB; got != [3]uint8{1, 0, 1} {
+		t.Fatalf("block average is %v, expected [1 0 1].", got)
+	}
+
+	truncated := pixelate
+	truncated.Result = withRect(t, pixelate.Result, block, [3]uint8{0, 0, 0})
+	assertNotSolved(t, truncated)
+
+	half_down := pixelate
+	half_down.Result = withRect(t, pixelate.Result, block, [3]uint8{0, 0, 1})
+	assertNotSolved(t, half_down)
+}
+
+func TestPixelateRegionEdge(t *testing.T) {
+	img := halvesImage(t)
+
+	pixelate, err := NewPixelate(img, Rect{X: 0, Y: 0, Width: 2, Height: 4}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The pixel right of the block is outside of the region, so it keeps its color
+	pixelate.Result = withPixel(t, pixelate.Result, 1, 2, [3]uint8{1, 0, 1})
+	assertNotSolved(t, pixelate)
+}
@@ -15,14 +15,20 @@ import (
 )
 
 type PCD_Keys struct {
-	ProvingKey   groth16.ProvingKey
-	VerifyingKey groth16.VerifyingKey
+	ProvingKey     groth16.ProvingKey
+	VerifyingKey   groth16.VerifyingKey
+	Transformation string     // Type of the Transformation the keys were generated for
+	Size           image.Size // Size of the input images the circuit was compiled for
+	Circuit_Hash   []byte     // Fingerprint of the compiled constraint system; see CircuitHash()
+	Params         string     // Compile-time parameters that the circuit type only fingerprints, if any
 }
 
+// Proof attached to a photograph. The PCD_Keys are not embedded: the verifying key is only referenced by its
+// fingerprint, so viewers look it up in a key store they trust instead of trusting a key supplied with the photo.
 type Gnark_Proof struct {
-	Gnark_Keys     PCD_Keys
-	Gnark_Proof    groth16.Proof
-	Public_Witness witness.Witness
+	Gnark_Proof     groth16.Proof
+	Public_Witness  witness.Witness
+	VerifyingKey_ID []byte // Fingerprint of the verifying key; see VerifyingKeyID()
 }
 
 // This function can be used to generate a new secret key. Used only by camera.
@@ -55,36 +61,53 @@ func Prove_Originality(img image.Image, sk signature.Signer, PCD_Keys map[string
 		return Gnark_Proof{}, fmt.Errorf("ERROR: transformation.ToFr() while taking a random photo.")
 	}
 
+	return prove(circuit, PCD_Keys)
+}
+
+// Proves that the assigned circuit is satisfied, using the proving key generated for the circuit's type.
+// Shared by Prove_Originality() and Prove_Edit().
+func prove(circuit TransformationCircuit, PCD_Keys map[string]PCD_Keys) (Gnark_Proof, error) {
+
+	pcd_keys, ok := PCD_Keys[circuit.GetType()]
+	if !ok {
+		return Gnark_Proof{}, fmt.Errorf("ERROR: no PCD_Keys for %s; was it a permissible transformation in the Generator?", circuit.GetType())
+	}
+
 	fmt.Println("Creating image's circuit Witness...")
 	// Create the secret witness from the circuit
 	secret_witness, err := frontend.NewWitness(circuit, ecc.BN254.ScalarField())
 	if err != nil {
-		return Gnark_Proof{}, fmt.Errorf("ERROR: frontend.NewWitness() while taking a random photo.")
+		return Gnark_Proof{}, fmt.Errorf("ERROR: frontend.NewWitness() while proving %s.", circuit.GetType())
 	}
 
 	fmt.Println("Compiling image circuit into constraint system...")
 	// Set the security parameter and compile a constraint system (aka compliance_predicate)
 	compliance_predicate, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
 	if err != nil {
-		return Gnark_Proof{}, fmt.Errorf("ERROR: frontend.Compile() while taking a random photo.")
+		return Gnark_Proof{}, fmt.Errorf("ERROR: frontend.Compile() while proving %s.", circuit.GetType())
 	}
 
 	fmt.Println("Proving compliance predicate...")
 	// Prove the secret witness adheres to the compliance predicate, using the given proving key
-	proof, err := groth16.Prove(compliance_predicate, PCD_Keys["id_Fr"].ProvingKey, secret_witness)
+	proof, err := groth16.Prove(compliance_predicate, pcd_keys.ProvingKey, secret_witness)
 	if err != nil {
-		return Gnark_Proof{}, fmt.Errorf("ERROR: frontend.Prove() failed inside the camera.")
+		return Gnark_Proof{}, fmt.Errorf("ERROR: groth16.Prove() failed for %s.", circuit.GetType())
 	}
 
 	public_witness, err := secret_witness.Public()
 	if err != nil {
-		return Gnark_Proof{}, fmt.Errorf("ERROR: secret_witness.Public() while taking a random photo.")
+		return Gnark_Proof{}, fmt.Errorf("ERROR: secret_witness.Public() while proving %s.", circuit.GetType())
+	}
+
+	vk_id, err := VerifyingKeyID(pcd_keys.VerifyingKey)
+	if err != nil {
+		return Gnark_Proof{}, fmt.Errorf("ERROR: VerifyingKeyID() while proving %s.", circuit.GetType())
 	}
 
 	gnark_proof := Gnark_Proof{
-		Gnark_Keys:     PCD_Keys["id_Fr"],
-		Gnark_Proof:    proof,
-		Public_Witness: public_witness,
+		Gnark_Proof:     proof,
+		Public_Witness:  public_witness,
+		VerifyingKey_ID: vk_id,
 	}
 
 	return gnark_proof, err
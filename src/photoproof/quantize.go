@@ -0,0 +1,63 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// A Quantize Transformation reduces every channel to its Bits most significant bits: v >> (8-Bits) << (8-Bits).
+type QuantizeTransformation struct {
+	Bits int
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewQuantize(img image.Image, bits int) (QuantizeTransformation, error) {
+	quantize := QuantizeTransformation{Bits: bits}
+	if err := quantize.checkBits(); err != nil {
+		return QuantizeTransformation{}, err
+	}
+
+	shift := 8 - bits
+	quantized, err := mapPixels(img, func(pxl image.Pixel) [3]uint8 {
+		return [3]uint8{pxl.RGB[0] >> shift << shift, pxl.RGB[1] >> shift << shift, pxl.RGB[2] >> shift << shift}
+	})
+	if err != nil {
+		return QuantizeTransformation{}, err
+	}
+
+	quantize.EditImages = EditImages{Img: img, Result: quantized}
+
+	return quantize, err
+}
+
+func (quantize QuantizeTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	if err := quantize.checkBits(); err != nil {
+		return nil, err
+	}
+
+	return &QuantizeCircuit{EditCircuit: quantize.ToFrEdit(), Bits: quantize.Bits}, nil
+}
+
+func (quantize QuantizeTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	if err := quantize.checkBits(); err != nil {
+		return nil, err
+	}
+
+	return &QuantizeCircuit{EditCircuit: NewEditCircuit(size, size), Bits: quantize.Bits}, nil
+}
+
+func (quantize QuantizeTransformation) GetType() string {
+	return "quantize"
+}
+
+func (quantize QuantizeTransformation) checkBits() error {
+	if quantize.Bits < 1 || quantize.Bits > 8 {
+		return fmt.Errorf("ERROR: quantization to %d bits is not within 1..8.", quantize.Bits)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Proves that every channel of Out is the matching channel of In with its 8-Bits least significant bits cleared.
+type QuantizeCircuit struct {
+	EditCircuit
+	Bits int `gnark:"-"`
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit QuantizeCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit QuantizeCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	for i := range circuit.Out.Pixels {
+		in := circuit.In.Pixels[i]
+		out := circuit.Out.Pixels[i]
+
+		for c := 0; c < 3; c++ {
+			// Keep the top Bits bits of the channel's decomposition, and replace the others by zeros
+			bits := api.ToBinary(in.RGB[c], 8)
+			for b := 0; b < 8-circuit.Bits; b++ {
+				bits[b] = 0
+			}
+
+			api.AssertIsEqual(out.RGB[c], api.FromBinary(bits...))
+		}
+	}
+
+	return nil
+}
+
+func (circuit QuantizeCircuit) GetType() string {
+	return fmt.Sprintf("quantize_Fr_%s_%dbits", circuit.sizes(), circuit.Bits)
+}
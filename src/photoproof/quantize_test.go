@@ -0,0 +1,25 @@
+package photoproof
+
+import "testing"
+
+func TestQuantize(t *testing.T) {
+	// 0x1f keeps 0x10 with 4 bits; rounding to the nearest level would give 0x20
+	img := newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+		return [3]uint8{0x1f, uint8(16*row + col), 0xff}
+	})
+
+	quantize, err := NewQuantize(img, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, quantize)
+
+	rounded := quantize
+	rounded.Result = withPixel(t, quantize.Result, 0, 0, [3]uint8{0x20, 0x00, 0xf0})
+	assertNotSolved(t, rounded)
+
+	// One of the dropped low bits is kept
+	kept := quantize
+	kept.Result = withPixel(t, quantize.Result, 0, 0, [3]uint8{0x11, 0x00, 0xf0})
+	assertNotSolved(t, kept)
+}
@@ -0,0 +1,110 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// A Redact Transformation sets every pixel inside one or more rectangles to a fixed Color, e.g. to hide faces and
+// licence plates, and leaves every other pixel unchanged.
+// The admin sets the number of rectangles (Count) and whether they are public, when the PCD keys are generated.
+// Edits with fewer rectangles are padded with empty ones.
+type RedactTransformation struct {
+	Rects       []Rect
+	Color       [3]uint8
+	Count       int  // Admin-configured number of rectangles
+	PublicRects bool // Whether the rectangles are part of the public statement; otherwise only the Color is known
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewRedact(img image.Image, rects []Rect, color [3]uint8, count int, publicRects bool) (RedactTransformation, error) {
+	if len(rects) > count {
+		return RedactTransformation{}, fmt.Errorf("ERROR: %d redacted rectangles, at most %d are allowed.", len(rects), count)
+	}
+
+	// Pad with empty rectangles
+	padded := make([]Rect, count)
+	copy(padded, rects)
+
+	redact := RedactTransformation{Rects: padded, Color: color, Count: count, PublicRects: publicRects}
+	if err := redact.checkBounds(img.Size()); err != nil {
+		return RedactTransformation{}, err
+	}
+
+	redacted, err := newImageFrom(img.Size(), func(row int, col int) [3]uint8 {
+		for _, rect := range redact.Rects {
+			if rect.Contains(row, col) {
+				return color
+			}
+		}
+		return img.At(row, col).RGB
+	})
+	if err != nil {
+		return RedactTransformation{}, err
+	}
+
+	redact.EditImages = EditImages{Img: img, Result: redacted}
+
+	return redact, err
+}
+
+func (redact RedactTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	if err := redact.checkBounds(redact.Img.Size()); err != nil {
+		return nil, err
+	}
+
+	circuit := redact.newCircuit(redact.ToFrEdit())
+	circuit.Color = image.Pack(redact.Color)
+
+	for i, rect := range redact.Rects {
+		circuit.Rects[i] = rect.ToFr()
+
+		if redact.PublicRects {
+			circuit.Public_Rects[i] = rect.ToFr()
+		} else {
+			circuit.Public_Rects[i] = Rect{}.ToFr()
+		}
+	}
+
+	return circuit, nil
+}
+
+func (redact RedactTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	if redact.Count <= 0 {
+		return nil, fmt.Errorf("ERROR: a redaction needs at least one rectangle, got %d.", redact.Count)
+	}
+
+	return redact.newCircuit(NewEditCircuit(size, size)), nil
+}
+
+func (redact RedactTransformation) GetType() string {
+	return "redact"
+}
+
+func (redact RedactTransformation) newCircuit(images EditCircuit) *RedactCircuit {
+	return &RedactCircuit{
+		EditCircuit:    images,
+		Rects:          make([]FrRect, redact.Count),
+		Public_Rects:   make([]FrRect, redact.Count),
+		RectsArePublic: redact.PublicRects,
+	}
+}
+
+// Returns an error unless there are Count rectangles, each within an image of the given size.
+func (redact RedactTransformation) checkBounds(size image.Size) error {
+	if redact.Count <= 0 || len(redact.Rects) != redact.Count {
+		return fmt.Errorf("ERROR: a redaction needs %d rectangles, got %d.", max(redact.Count, 1), len(redact.Rects))
+	}
+
+	for _, rect := range redact.Rects {
+		if err := rect.checkBounds(size); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Proves that every pixel of Out inside one of the Rects is Color, and that every other pixel equals the pixel of In.
+type RedactCircuit struct {
+	EditCircuit
+	Color          frontend.Variable `gnark:",public"` // Packed fill color
+	Rects          []FrRect
+	Public_Rects   []FrRect `gnark:",public"` // Equal to Rects if RectsArePublic, empty rectangles otherwise
+	RectsArePublic bool     `gnark:"-"`
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit RedactCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit RedactCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	size := circuit.In.Size
+
+	for i, rect := range circuit.Rects {
+		public := circuit.Public_Rects[i]
+
+		if circuit.RectsArePublic {
+			api.AssertIsEqual(public.X, rect.X)
+			api.AssertIsEqual(public.Y, rect.Y)
+			api.AssertIsEqual(public.Width, rect.Width)
+			api.AssertIsEqual(public.Height, rect.Height)
+		} else {
+			api.AssertIsEqual(public.X, 0)
+			api.AssertIsEqual(public.Y, 0)
+			api.AssertIsEqual(public.Width, 0)
+			api.AssertIsEqual(public.Height, 0)
+		}
+
+		rect.assertBounds(api, size)
+	}
+
+	for i := range circuit.Out.Pixels {
+		in := circuit.In.Pixels[i]
+		out := circuit.Out.Pixels[i]
+
+		row, col := i/size.Width, i%size.Width
+
+		var redacted frontend.Variable = 0
+		for _, rect := range circuit.Rects {
+			redacted = api.Or(redacted, rect.contains(api, row, col, size))
+		}
+
+		api.AssertIsEqual(out.Packed, api.Select(redacted, circuit.Color, in.Packed))
+	}
+
+	return nil
+}
+
+func (circuit RedactCircuit) GetType() string {
+	visibility := "private"
+	if circuit.RectsArePublic {
+		visibility = "public"
+	}
+
+	return fmt.Sprintf("redact_Fr_%s_%drects_%s", circuit.sizes(), len(circuit.Rects), visibility)
+}
@@ -0,0 +1,65 @@
+package photoproof
+
+import "testing"
+
+func TestRedact(t *testing.T) {
+	img := randomImage(t, testSize)
+	color := [3]uint8{0, 0, 0}
+	rect := Rect{X: 1, Y: 1, Width: 2, Height: 2}
+
+	for _, publicRects := range []bool{false, true} {
+		redact, err := NewRedact(img, []Rect{rect}, color, 2, publicRects)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSolved(t, redact)
+
+		// A pixel next to the rectangle is redacted too
+		over := redact
+		over.Result = withPixel(t, redact.Result, 1, 3, color)
+		assertNotSolved(t, over)
+
+		// A pixel inside the rectangle keeps its color
+		under := redact
+		under.Result = withPixel(t, redact.Result, 2, 2, img.At(2, 2).RGB)
+		if SameCommitment(under.Result, redact.Result) {
+			t.Fatal("test image is already redacted.")
+		}
+		assertNotSolved(t, under)
+	}
+}
+
+func TestRedactPublicRects(t *testing.T) {
+	img := randomImage(t, testSize)
+
+	redact, err := NewRedact(img, []Rect{{X: 1, Y: 1, Width: 2, Height: 2}}, [3]uint8{}, 1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The public rectangles must be the ones actually redacted
+	err = solveWith(redact, func(assignment TransformationCircuit) {
+		assignment.(*RedactCircuit).Public_Rects[0].X = 0
+	})
+	if err == nil {
+		t.Fatal("redact edit with other public rectangles should not be solved.")
+	}
+}
+
+func TestRedactBounds(t *testing.T) {
+	img := randomImage(t, testSize)
+
+	// The secret rectangle spills over the right edge, into the next row
+	redact, err := NewRedact(img, []Rect{{X: 3, Y: 0, Width: 1, Height: 1}}, [3]uint8{}, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	redact.Result = withPixel(t, redact.Result, 1, 0, [3]uint8{})
+
+	err = solveWith(redact, func(assignment TransformationCircuit) {
+		assignment.(*RedactCircuit).Rects[0].Width = 2
+	})
+	if err == nil {
+		t.Fatal("redact edit with an out of bounds rectangle should not be solved.")
+	}
+}
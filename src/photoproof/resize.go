@@ -0,0 +1,88 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+type ResizeFilter string
+
+const (
+	BoxFilter     ResizeFilter = "box"     // Average of every Factor x Factor square, rounded as divRound does
+	NearestFilter ResizeFilter = "nearest" // Center pixel of every Factor x Factor square; see nearestOffset()
+)
+
+// A Resize Transformation downsamples the image by an integer Factor, e.g. for thumbnails and web renditions.
+// The output is Factor times smaller in both dimensions, which must be multiples of Factor.
+type ResizeTransformation struct {
+	Factor int
+	Filter ResizeFilter
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewResize(img image.Image, factor int, filter ResizeFilter) (ResizeTransformation, error) {
+	resize := ResizeTransformation{Factor: factor, Filter: filter}
+
+	out, err := resize.outputSize(img.Size())
+	if err != nil {
+		return ResizeTransformation{}, err
+	}
+
+	resized, err := newImageFrom(out, func(row int, col int) [3]uint8 {
+		if filter == NearestFilter {
+			offset := nearestOffset(factor)
+			return img.At(row*factor+offset, col*factor+offset).RGB
+		}
+		return blockAverage(img, row*factor, col*factor, factor)
+	})
+	if err != nil {
+		return ResizeTransformation{}, err
+	}
+
+	resize.EditImages = EditImages{Img: img, Result: resized}
+
+	return resize, err
+}
+
+// Returns the row and column of the pixel NearestFilter samples within its Factor x Factor square: the center pixel
+// for odd factors and, for even factors, the top-left one of the four center pixels, i.e. (Factor-1)/2.
+func nearestOffset(factor int) int {
+	return (factor - 1) / 2
+}
+
+func (resize ResizeTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	if _, err := resize.outputSize(resize.Img.Size()); err != nil {
+		return nil, err
+	}
+
+	return &ResizeCircuit{EditCircuit: resize.ToFrEdit(), Factor: resize.Factor, Filter: resize.Filter}, nil
+}
+
+func (resize ResizeTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	out, err := resize.outputSize(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResizeCircuit{EditCircuit: NewEditCircuit(size, out), Factor: resize.Factor, Filter: resize.Filter}, nil
+}
+
+func (resize ResizeTransformation) GetType() string {
+	return "resize"
+}
+
+// Returns the size of the resized image, for an input image of the given size.
+func (resize ResizeTransformation) outputSize(in image.Size) (image.Size, error) {
+	if resize.Filter != BoxFilter && resize.Filter != NearestFilter {
+		return image.Size{}, fmt.Errorf("ERROR: unknown resize filter %q.", resize.Filter)
+	}
+	if resize.Factor <= 0 || in.Width%resize.Factor != 0 || in.Height%resize.Factor != 0 {
+		return image.Size{}, fmt.Errorf("ERROR: a %s image cannot be downsampled by %d.", in, resize.Factor)
+	}
+
+	return image.Size{Width: in.Width / resize.Factor, Height: in.Height / resize.Factor}, nil
+}
@@ -0,0 +1,56 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Proves that every pixel of Out is computed by Filter from the matching Factor x Factor square of In.
+type ResizeCircuit struct {
+	EditCircuit
+	Factor int          `gnark:"-"`
+	Filter ResizeFilter `gnark:"-"`
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit ResizeCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit ResizeCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	factor := circuit.Factor
+	in_width := circuit.In.Size.Width
+
+	for i, out := range circuit.Out.Pixels {
+		row, col := i/circuit.Out.Size.Width, i%circuit.Out.Size.Width
+
+		if circuit.Filter == NearestFilter {
+			offset := nearestOffset(factor)
+			in := circuit.In.Pixels[(row*factor+offset)*in_width+col*factor+offset]
+			api.AssertIsEqual(out.Packed, in.Packed)
+			continue
+		}
+
+		for c := 0; c < 3; c++ {
+			average, err := frBlockAverage(api, circuit.In, row*factor, col*factor, factor, c)
+			if err != nil {
+				return err
+			}
+
+			api.AssertIsEqual(out.RGB[c], average)
+		}
+	}
+
+	return nil
+}
+
+func (circuit ResizeCircuit) GetType() string {
+	return fmt.Sprintf("resize_Fr_%s_%s", circuit.sizes(), circuit.Filter)
+}
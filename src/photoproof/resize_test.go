@@ -0,0 +1,60 @@
+package photoproof
+
+import (
+	"testing"
+
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+func TestResizeRounding(t *testing.T) {
+	img := halvesImage(t)
+
+	resize, err := NewResize(img, 2, BoxFilter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, resize)
+
+	// Halves round up, as they do when pixelating
+	if got := resize.Result.At(0, 0).RGB; got != [3]uint8{1, 0, 1} {
+		t.Fatalf("box average is %v, expected [1 0 1].", got)
+	}
+
+	resize.Result = withPixel(t, resize.Result, 0, 0, [3]uint8{0, 0, 1})
+	assertNotSolved(t, resize)
+}
+
+func TestResizeFilters(t *testing.T) {
+	img := halvesImage(t)
+
+	box, err := NewResize(img, 2, BoxFilter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nearest, err := NewResize(img, 2, NearestFilter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, nearest)
+
+	// Each filter's output does not solve the other filter's circuit
+	box.Result, nearest.Result = nearest.Result, box.Result
+	assertNotSolved(t, box)
+	assertNotSolved(t, nearest)
+}
+
+func TestResizeNearest(t *testing.T) {
+	img := randomImage(t, image.Size{Width: 6, Height: 6})
+
+	// Center pixel for odd factors, top-left of the four center pixels for even ones
+	for factor, offset := range map[int]int{2: 0, 3: 1, 6: 2} {
+		resize, err := NewResize(img, factor, NearestFilter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := resize.Result.At(0, 0).RGB, img.At(offset, offset).RGB; got != want {
+			t.Fatalf("factor %d: sampled %v, expected the pixel at (%d, %d), %v.", factor, got, offset, offset, want)
+		}
+		assertSolved(t, resize)
+	}
+}
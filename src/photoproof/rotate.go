@@ -0,0 +1,88 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+type Rotation string
+
+const (
+	Rotate90  Rotation = "rotate90"  // Clockwise
+	Rotate180 Rotation = "rotate180" // Upside down
+	Rotate270 Rotation = "rotate270" // Clockwise, i.e. 90 degrees counterclockwise
+	Transpose Rotation = "transpose" // Mirror along the main diagonal
+)
+
+// A Rotate Transformation rotates the image by a multiple of 90 degrees, or transposes it.
+// It is proven by a PermutationCircuit, since it only moves pixels around.
+type RotateTransformation struct {
+	Rotation Rotation
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewRotate(img image.Image, rotation Rotation) (RotateTransformation, error) {
+	rotate := RotateTransformation{Rotation: rotation}
+
+	m, out, err := rotate.pixelMap(img.Size())
+	if err != nil {
+		return RotateTransformation{}, err
+	}
+
+	rotated, err := m.Apply(img, out)
+	if err != nil {
+		return RotateTransformation{}, err
+	}
+
+	rotate.EditImages = EditImages{Img: img, Result: rotated}
+
+	return rotate, err
+}
+
+func (rotate RotateTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	m, _, err := rotate.pixelMap(rotate.Img.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	return &PermutationCircuit{EditCircuit: rotate.ToFrEdit(), Name: string(rotate.Rotation), Map: m}, nil
+}
+
+func (rotate RotateTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	m, out, err := rotate.pixelMap(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PermutationCircuit{EditCircuit: NewEditCircuit(size, out), Name: string(rotate.Rotation), Map: m}, nil
+}
+
+func (rotate RotateTransformation) GetType() string {
+	return "rotate"
+}
+
+// Returns the rotation's PixelMap and output size, for an input image of the given size.
+func (rotate RotateTransformation) pixelMap(in image.Size) (PixelMap, image.Size, error) {
+	swapped := image.Size{Width: in.Height, Height: in.Width}
+
+	switch rotate.Rotation {
+	case Rotate90:
+		// out(r, c) = in(H-1-c, r)
+		return PixelMap{Row: [3]int{0, -1, in.Height - 1}, Col: [3]int{1, 0, 0}}, swapped, nil
+	case Rotate180:
+		// out(r, c) = in(H-1-r, W-1-c)
+		return PixelMap{Row: [3]int{-1, 0, in.Height - 1}, Col: [3]int{0, -1, in.Width - 1}}, in, nil
+	case Rotate270:
+		// out(r, c) = in(c, W-1-r)
+		return PixelMap{Row: [3]int{0, 1, 0}, Col: [3]int{-1, 0, in.Width - 1}}, swapped, nil
+	case Transpose:
+		// out(r, c) = in(c, r)
+		return PixelMap{Row: [3]int{0, 1, 0}, Col: [3]int{1, 0, 0}}, swapped, nil
+	}
+
+	return PixelMap{}, image.Size{}, fmt.Errorf("ERROR: unknown rotation %q.", rotate.Rotation)
+}
@@ -0,0 +1,43 @@
+package photoproof
+
+import (
+	"testing"
+
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// Not square, so that the output of every rotation but Rotate180 has another size than its input.
+var rotateSize = image.Size{Width: 4, Height: 3}
+
+func TestRotate(t *testing.T) {
+	img := randomImage(t, rotateSize)
+
+	for _, rotation := range []Rotation{Rotate90, Rotate180, Rotate270, Transpose} {
+		rotate, err := NewRotate(img, rotation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSolved(t, rotate)
+	}
+}
+
+func TestRotateDirection(t *testing.T) {
+	img := randomImage(t, rotateSize)
+
+	// Each pair of rotations outputs images of the same size, whose pixels are in another order
+	pairs := [][2]Rotation{{Rotate90, Rotate270}, {Rotate90, Transpose}, {Rotate270, Transpose}}
+	for _, pair := range pairs {
+		rotate, err := NewRotate(img, pair[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		other, err := NewRotate(img, pair[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		rotate.Result = other.Result
+
+		assertNotSolved(t, rotate)
+	}
+}
@@ -3,15 +3,23 @@ package photoproof
 import (
 	"github.com/consensys/gnark-crypto/signature"
 	"github.com/consensys/gnark/frontend"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
 )
 
 type Transformation interface {
 	GetType() string
 	ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error)
+	NewCircuit(size image.Size) (TransformationCircuit, error) // Unassigned circuit for images of the given size; used by Generator
 }
 
 type TransformationCircuit interface {
-	GetType() string
+	GetType() string // Also identifies the circuit's PCD_Keys, so it must differ for every compiled shape of the circuit
 	Define(api frontend.API) error
 	GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error)
 }
+
+// Implemented by circuits whose type only holds a fingerprint of their compile-time parameters.
+// Generator records the parameters themselves in the circuit's PCD_Keys, so that they are kept in the manifest.
+type parameterizedCircuit interface {
+	params() string
+}
@@ -0,0 +1,102 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// A Watermark Transformation alpha-blends a Mark (e.g. an agency's logo) into the rectangle of the input whose top-left
+// pixel is at (X, Y), with opacity Alpha (0 keeps the input, 255 replaces it by the Mark):
+// out = round((Alpha*mark + (255-Alpha)*in) / 255), rounded as divRound does. Every other pixel is unchanged.
+// The Mark's commitment, the rectangle and Alpha are part of the public statement.
+type WatermarkTransformation struct {
+	X     int // Column of the Mark's top-left pixel
+	Y     int // Row of the Mark's top-left pixel
+	Mark  image.Image
+	Alpha uint8
+	EditImages
+}
+
+//----------------------------------------------------------------------------------------------------
+
+// Returns channel v blended with channel mark at opacity alpha, as computed by WatermarkCircuit.
+func blend(v uint8, mark uint8, alpha uint8) uint8 {
+	return uint8(divRoundInt(int(alpha)*int(mark)+(255-int(alpha))*int(v), 255))
+}
+
+func NewWatermark(img image.Image, mark image.Image, x int, y int, alpha uint8) (WatermarkTransformation, error) {
+	watermark := WatermarkTransformation{X: x, Y: y, Mark: mark, Alpha: alpha}
+	if err := watermark.checkBounds(img.Size()); err != nil {
+		return WatermarkTransformation{}, err
+	}
+
+	placement := watermark.rect()
+
+	marked, err := newImageFrom(img.Size(), func(row int, col int) [3]uint8 {
+		rgb := img.At(row, col).RGB
+		if !placement.Contains(row, col) {
+			return rgb
+		}
+
+		m := mark.At(row-y, col-x).RGB
+		return [3]uint8{blend(rgb[0], m[0], alpha), blend(rgb[1], m[1], alpha), blend(rgb[2], m[2], alpha)}
+	})
+	if err != nil {
+		return WatermarkTransformation{}, err
+	}
+
+	watermark.EditImages = EditImages{Img: img, Result: marked}
+
+	return watermark, err
+}
+
+func (watermark WatermarkTransformation) ToFr(sk signature.Signer, public_key []byte) (TransformationCircuit, error) {
+	if err := watermark.checkBounds(watermark.Img.Size()); err != nil {
+		return nil, err
+	}
+
+	circuit := &WatermarkCircuit{
+		EditCircuit: watermark.ToFrEdit(),
+		Mark:        watermark.Mark.ToFr(),
+		X:           watermark.X,
+		Y:           watermark.Y,
+		Alpha:       watermark.Alpha,
+		Placement:   watermark.rect(),
+	}
+
+	return circuit, nil
+}
+
+func (watermark WatermarkTransformation) NewCircuit(size image.Size) (TransformationCircuit, error) {
+	if err := watermark.checkBounds(size); err != nil {
+		return nil, err
+	}
+
+	circuit := &WatermarkCircuit{
+		EditCircuit: NewEditCircuit(size, size),
+		Mark:        image.NewFrImage(watermark.Mark.Size()),
+		Placement:   watermark.rect(),
+	}
+
+	return circuit, nil
+}
+
+func (watermark WatermarkTransformation) GetType() string {
+	return "watermark"
+}
+
+// Returns the rectangle the Mark is blended into.
+func (watermark WatermarkTransformation) rect() Rect {
+	return Rect{X: watermark.X, Y: watermark.Y, Width: watermark.Mark.Width, Height: watermark.Mark.Height}
+}
+
+// Returns an error unless the Mark is a valid image that fits in an image of the given size at (X, Y).
+func (watermark WatermarkTransformation) checkBounds(size image.Size) error {
+	if err := watermark.Mark.Size().Validate(); err != nil {
+		return fmt.Errorf("ERROR: invalid watermark: %w", err)
+	}
+
+	return watermark.rect().checkBounds(size)
+}
@@ -0,0 +1,75 @@
+package photoproof
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark/frontend"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+// Proves that every pixel of Out inside Placement is the matching pixel of In blended with the Mark at opacity Alpha,
+// where the Mark opens its public commitment, and that every other pixel of Out equals the pixel of In.
+type WatermarkCircuit struct {
+	EditCircuit
+	Mark      image.FrImage
+	X         frontend.Variable `gnark:",public"` // Column of the Mark's top-left pixel
+	Y         frontend.Variable `gnark:",public"` // Row of the Mark's top-left pixel
+	Alpha     frontend.Variable `gnark:",public"`
+	Placement Rect              `gnark:"-"`
+}
+
+// GeneratePCD_Keys implements TransformationCircuit.
+func (circuit WatermarkCircuit) GeneratePCD_Keys(sk signature.Signer) (PCD_Keys, error) {
+	return generatePCD_Keys(&circuit)
+}
+
+func (circuit WatermarkCircuit) Define(api frontend.API) error {
+	err := circuit.AssertImages(api)
+	if err != nil {
+		return err
+	}
+
+	err = circuit.Mark.AssertCommitment(api)
+	if err != nil {
+		return err
+	}
+
+	api.AssertIsEqual(circuit.X, circuit.Placement.X)
+	api.AssertIsEqual(circuit.Y, circuit.Placement.Y)
+	assertInRange(api, circuit.Alpha, 255)
+
+	width := circuit.In.Size.Width
+	inverse_alpha := api.Sub(255, circuit.Alpha)
+
+	for i := range circuit.Out.Pixels {
+		in := circuit.In.Pixels[i]
+		out := circuit.Out.Pixels[i]
+
+		row, col := i/width, i%width
+		if !circuit.Placement.Contains(row, col) {
+			api.AssertIsEqual(out.Packed, in.Packed)
+			continue
+		}
+
+		mark := circuit.Mark.Pixels[(row-circuit.Placement.Y)*circuit.Placement.Width+col-circuit.Placement.X]
+
+		for c := 0; c < 3; c++ {
+			// Both weights lie in [0, 255] and sum to 255, so the weighted sum lies in [0, 255*255]
+			sum := api.Add(api.Mul(circuit.Alpha, mark.RGB[c]), api.Mul(inverse_alpha, in.RGB[c]))
+
+			blended, err := divRound(api, sum, 255, 255*255)
+			if err != nil {
+				return err
+			}
+
+			api.AssertIsEqual(out.RGB[c], blended)
+		}
+	}
+
+	return nil
+}
+
+func (circuit WatermarkCircuit) GetType() string {
+	return fmt.Sprintf("watermark_Fr_%s_%s+%d+%d", circuit.sizes(), circuit.Mark.Size, circuit.Placement.X, circuit.Placement.Y)
+}
@@ -0,0 +1,82 @@
+package photoproof
+
+import (
+	"testing"
+
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+)
+
+var markSize = image.Size{Width: 2, Height: 3}
+
+func TestWatermarkAlpha(t *testing.T) {
+	img := randomImage(t, testSize)
+	mark := randomImage(t, markSize)
+	placement := Rect{X: 1, Y: 1, Width: markSize.Width, Height: markSize.Height}
+
+	// A transparent mark leaves the input unchanged, an opaque one replaces the pixels it covers
+	transparent, err := NewWatermark(img, mark, 1, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !SameCommitment(transparent.Result, img) {
+		t.Fatal("transparent mark changed the image.")
+	}
+	assertSolved(t, transparent)
+
+	opaque, err := NewWatermark(img, mark, 1, 1, 255)
+	if err != nil {
+		t.Fatal(err)
+	}
+	covered := newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+		if placement.Contains(row, col) {
+			return mark.At(row-1, col-1).RGB
+		}
+		return img.At(row, col).RGB
+	})
+	if !SameCommitment(opaque.Result, covered) {
+		t.Fatal("opaque mark did not replace the pixels it covers.")
+	}
+	assertSolved(t, opaque)
+}
+
+func TestWatermarkRounding(t *testing.T) {
+	black := newTestImage(t, testSize, func(row int, col int) [3]uint8 {
+		return [3]uint8{}
+	})
+	mark := newTestImage(t, markSize, func(row int, col int) [3]uint8 {
+		return [3]uint8{1, 1, 1}
+	})
+
+	// 128/255 of 1 rounds up to 1
+	watermark, err := NewWatermark(black, mark, 1, 1, 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSolved(t, watermark)
+
+	watermark.Result = black
+	assertNotSolved(t, watermark)
+}
+
+func TestWatermarkMark(t *testing.T) {
+	img := randomImage(t, testSize)
+	mark := randomImage(t, markSize)
+
+	watermark, err := NewWatermark(img, mark, 1, 1, 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The output was blended with another mark than the committed one. The mark's pixel moves by at least 128, since
+	// blending could round a smaller change away.
+	rgb := mark.At(0, 0).RGB
+	for c := range rgb {
+		if rgb[c] < 128 {
+			rgb[c] = 255
+		} else {
+			rgb[c] = 0
+		}
+	}
+	watermark.Mark = withPixel(t, mark, 0, 0, rgb)
+	assertNotSolved(t, watermark)
+}
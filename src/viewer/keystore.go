@@ -0,0 +1,111 @@
+package viewer
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/drakstik/PhotoGnark_V1/src/photoproof"
+)
+
+// A verifying key the viewer trusts, along with the type of circuit it verifies.
+type TrustedKey struct {
+	Type           string
+	Transformation string // Type of the Transformation the key was generated for
+	VerifyingKey   groth16.VerifyingKey
+}
+
+// Local store of trusted verifying keys, indexed by fingerprint (see photoproof.VerifyingKeyID).
+// Photographs only reference their verifying key, so a photo can never bring its own key.
+type KeyStore struct {
+	keys map[string]TrustedKey
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewKeyStore() *KeyStore {
+	return &KeyStore{keys: map[string]TrustedKey{}}
+}
+
+// Loads the verifying keys persisted by photoproof.SavePCDKeys() into a new KeyStore.
+func LoadKeyStore(dir string) (*KeyStore, error) {
+	pcd_keys, err := photoproof.LoadVerifyingKeys(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := NewKeyStore()
+	if err := ks.AddPCDKeys(pcd_keys); err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
+
+// Trusts the verifying key of the PCD_Keys for the given circuit type and returns its fingerprint.
+func (ks *KeyStore) Add(trType string, keys photoproof.PCD_Keys) ([]byte, error) {
+	vk_id, err := photoproof.VerifyingKeyID(keys.VerifyingKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ks.keys[hex.EncodeToString(vk_id)] = TrustedKey{Type: trType, Transformation: keys.Transformation, VerifyingKey: keys.VerifyingKey}
+
+	return vk_id, nil
+}
+
+// Trusts the verifying key of every given set of PCD_Keys.
+func (ks *KeyStore) AddPCDKeys(m map[string]photoproof.PCD_Keys) error {
+	for trType, keys := range m {
+		if _, err := ks.Add(trType, keys); err != nil {
+			return fmt.Errorf("ERROR: adding verifying key for %s: %w", trType, err)
+		}
+	}
+
+	return nil
+}
+
+func (ks *KeyStore) Lookup(vk_id []byte) (TrustedKey, bool) {
+	key, ok := ks.keys[hex.EncodeToString(vk_id)]
+	return key, ok
+}
+
+// Returns the trusted verifying key referenced by the proof, which must verify circuits of the given type.
+func (ks *KeyStore) VerifyingKey(proof photoproof.Gnark_Proof, trType string) (groth16.VerifyingKey, error) {
+	key, err := ks.trusted(proof)
+	if err != nil {
+		return nil, err
+	}
+	if key.Type != trType {
+		return nil, fmt.Errorf("ERROR: verifying key %x is for %s, expected %s.", proof.VerifyingKey_ID, key.Type, trType)
+	}
+
+	return key.VerifyingKey, nil
+}
+
+// Returns the trusted verifying key referenced by an edit proof, along with the edit it verifies. Any trusted edit
+// is accepted, but the keys of Identity Circuits are not, since they prove originality rather than an edit.
+func (ks *KeyStore) EditVerifyingKey(proof photoproof.Gnark_Proof) (TrustedKey, error) {
+	key, err := ks.trusted(proof)
+	if err != nil {
+		return TrustedKey{}, err
+	}
+	if key.Transformation == (photoproof.IdentityTransformation{}).GetType() {
+		return TrustedKey{}, fmt.Errorf("ERROR: verifying key %x is for %s, expected an edit.", proof.VerifyingKey_ID, key.Type)
+	}
+
+	return key, nil
+}
+
+func (ks *KeyStore) trusted(proof photoproof.Gnark_Proof) (TrustedKey, error) {
+	if ks == nil {
+		return TrustedKey{}, fmt.Errorf("ERROR: no trusted verifying keys.")
+	}
+
+	key, ok := ks.Lookup(proof.VerifyingKey_ID)
+	if !ok {
+		return TrustedKey{}, fmt.Errorf("ERROR: verifying key %x is not trusted.", proof.VerifyingKey_ID)
+	}
+
+	return key, nil
+}
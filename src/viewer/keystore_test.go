@@ -0,0 +1,142 @@
+package viewer
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+	"github.com/drakstik/PhotoGnark_V1/src/photoproof"
+)
+
+// Keys of a camera for small images that can invert its photos, generated once for every test.
+var generatedKeys = sync.OnceValues(func() (map[string]photoproof.PCD_Keys, error) {
+	permissible := []photoproof.Transformation{photoproof.IdentityTransformation{}, photoproof.InvertTransformation{}}
+	return photoproof.Generator(nil, permissible, image.Size{Width: 4, Height: 4})
+})
+
+// Returns a proof referencing the verifying key of the given transformation, along with its circuit type.
+func proofFor(t *testing.T, tr photoproof.Transformation) (photoproof.Gnark_Proof, string) {
+	t.Helper()
+
+	m, err := generatedKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for trType, keys := range m {
+		if keys.Transformation != tr.GetType() {
+			continue
+		}
+
+		vk_id, err := photoproof.VerifyingKeyID(keys.VerifyingKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return photoproof.Gnark_Proof{VerifyingKey_ID: vk_id}, trType
+	}
+
+	t.Fatalf("no PCD_Keys generated for %s.", tr.GetType())
+	return photoproof.Gnark_Proof{}, ""
+}
+
+func TestKeyStoreLookup(t *testing.T) {
+	m, err := generatedKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Keys are the same whether they are added directly or loaded from disk
+	added := NewKeyStore()
+	if err := added.AddPCDKeys(m); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := photoproof.SavePCDKeys(dir, m); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadKeyStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ks := range []*KeyStore{added, loaded} {
+		for trType, keys := range m {
+			vk_id, err := photoproof.VerifyingKeyID(keys.VerifyingKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			key, ok := ks.Lookup(vk_id)
+			if !ok {
+				t.Fatalf("verifying key of %s should be trusted.", trType)
+			}
+			if key.Type != trType || key.Transformation != keys.Transformation {
+				t.Fatalf("verifying key of %s is trusted as %s (%s).", trType, key.Type, key.Transformation)
+			}
+		}
+	}
+}
+
+func TestKeyStoreUnknownKey(t *testing.T) {
+	m, err := generatedKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ks := NewKeyStore()
+	if err := ks.AddPCDKeys(m); err != nil {
+		t.Fatal(err)
+	}
+
+	proof, trType := proofFor(t, photoproof.InvertTransformation{})
+	proof.VerifyingKey_ID[0] ^= 1
+
+	if _, ok := ks.Lookup(proof.VerifyingKey_ID); ok {
+		t.Fatal("unknown verifying key should not be trusted.")
+	}
+	if _, err := ks.VerifyingKey(proof, trType); err == nil {
+		t.Fatal("proof referencing an unknown verifying key should not get a key.")
+	}
+	if _, err := ks.EditVerifyingKey(proof); err == nil {
+		t.Fatal("edit proof referencing an unknown verifying key should not get a key.")
+	}
+
+	var empty *KeyStore
+	if _, err := empty.EditVerifyingKey(proof); err == nil {
+		t.Fatal("a user without a KeyStore should not trust any key.")
+	}
+}
+
+func TestKeyStoreTypes(t *testing.T) {
+	m, err := generatedKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ks := NewKeyStore()
+	if err := ks.AddPCDKeys(m); err != nil {
+		t.Fatal(err)
+	}
+
+	identity, identityType := proofFor(t, photoproof.IdentityTransformation{})
+	invert, invertType := proofFor(t, photoproof.InvertTransformation{})
+
+	if _, err := ks.VerifyingKey(identity, identityType); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.VerifyingKey(identity, invertType); err == nil {
+		t.Fatal("a trusted key should only verify circuits of its own type.")
+	}
+
+	// The keys of Identity Circuits prove originality, not edits
+	if _, err := ks.EditVerifyingKey(identity); err == nil {
+		t.Fatal("the verifying key of an Identity Circuit should not verify edits.")
+	}
+	key, err := ks.EditVerifyingKey(invert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.Transformation != (photoproof.InvertTransformation{}).GetType() {
+		t.Fatalf("edit key is for %s, expected %s.", key.Transformation, photoproof.InvertTransformation{}.GetType())
+	}
+}
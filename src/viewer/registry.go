@@ -0,0 +1,123 @@
+package viewer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+// ErrUnknownCamera is returned when a photograph was not signed by any camera in the viewer's registry.
+var ErrUnknownCamera = errors.New("unknown camera")
+
+type CameraStatus string
+
+const (
+	CameraActive    CameraStatus = "active"
+	CameraSuspended CameraStatus = "suspended"
+	CameraRevoked   CameraStatus = "revoked"
+)
+
+// A Secure Camera the viewer trusts, identified by its public key.
+// A zero ValidFrom or ValidUntil leaves that side of the validity window open.
+type RegisteredCamera struct {
+	ID           string
+	Manufacturer string
+	PublicKey    signature.PublicKey
+	ValidFrom    time.Time
+	ValidUntil   time.Time
+	Status       CameraStatus
+}
+
+// Registry of trusted Secure Camera public keys, indexed by camera ID.
+type Registry struct {
+	cameras map[string]RegisteredCamera
+	order   []string // IDs in registration order, so lookups are deterministic
+}
+
+//----------------------------------------------------------------------------------------------------
+
+func NewRegistry() *Registry {
+	return &Registry{cameras: map[string]RegisteredCamera{}}
+}
+
+// Adds a camera to the registry. Camera IDs and public keys must be unique.
+func (r *Registry) Register(cam RegisteredCamera) error {
+	if cam.ID == "" {
+		return fmt.Errorf("ERROR: camera ID is empty.")
+	}
+	if cam.PublicKey == nil {
+		return fmt.Errorf("ERROR: camera %s has no public key.", cam.ID)
+	}
+	if _, ok := r.cameras[cam.ID]; ok {
+		return fmt.Errorf("ERROR: camera %s is already registered.", cam.ID)
+	}
+	if other, ok := r.LookupKey(cam.PublicKey); ok {
+		return fmt.Errorf("ERROR: public key of camera %s is already registered to camera %s.", cam.ID, other.ID)
+	}
+	if cam.Status == "" {
+		cam.Status = CameraActive
+	}
+
+	r.cameras[cam.ID] = cam
+	r.order = append(r.order, cam.ID)
+
+	return nil
+}
+
+func (r *Registry) Lookup(id string) (RegisteredCamera, bool) {
+	cam, ok := r.cameras[id]
+	return cam, ok
+}
+
+// Returns the registered camera holding the given public key.
+func (r *Registry) LookupKey(pk signature.PublicKey) (RegisteredCamera, bool) {
+	for _, cam := range r.Cameras() {
+		if bytes.Equal(cam.PublicKey.Bytes(), pk.Bytes()) {
+			return cam, true
+		}
+	}
+
+	return RegisteredCamera{}, false
+}
+
+// Changes the status of a registered camera, e.g. to revoke a compromised device.
+func (r *Registry) SetStatus(id string, status CameraStatus) error {
+	cam, ok := r.cameras[id]
+	if !ok {
+		return fmt.Errorf("ERROR: camera %s: %w", id, ErrUnknownCamera)
+	}
+
+	cam.Status = status
+	r.cameras[id] = cam
+
+	return nil
+}
+
+// Returns all registered cameras, in registration order.
+func (r *Registry) Cameras() []RegisteredCamera {
+	cameras := make([]RegisteredCamera, 0, len(r.order))
+	for _, id := range r.order {
+		cameras = append(cameras, r.cameras[id])
+	}
+
+	return cameras
+}
+
+// Returns an error if the camera is not active or if t is outside of its validity window.
+// Viewers pass the verification time, since photographs do not carry a signed capture time.
+func (cam RegisteredCamera) CheckValid(t time.Time) error {
+	if cam.Status != CameraActive {
+		return fmt.Errorf("ERROR: camera %s is %s.", cam.ID, cam.Status)
+	}
+	if !cam.ValidFrom.IsZero() && t.Before(cam.ValidFrom) {
+		return fmt.Errorf("ERROR: camera %s is not valid before %s.", cam.ID, cam.ValidFrom.Format(time.RFC3339))
+	}
+	if !cam.ValidUntil.IsZero() && t.After(cam.ValidUntil) {
+		return fmt.Errorf("ERROR: camera %s expired on %s.", cam.ID, cam.ValidUntil.Format(time.RFC3339))
+	}
+
+	return nil
+}
@@ -1,41 +1,198 @@
 package viewer
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/consensys/gnark-crypto/signature"
 	"github.com/consensys/gnark/backend/groth16"
 	"github.com/drakstik/PhotoGnark_V1/src/camera"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
 	"github.com/drakstik/PhotoGnark_V1/src/photoproof"
 )
 
+// An edit whose proof the user verified. Only its public statement is known: the sizes and commitments of its
+// images, and the public parameters of its circuit.
+type VerifiedEdit struct {
+	Type           string       // Circuit type of the edit's verifying key
+	Transformation string       // Transformation type of the edit's verifying key, e.g. "curve"
+	In             image.Image  // Size and commitment of the edit's input
+	Out            image.Image  // Size and commitment of the edit's output
+	Parameters     []fr.Element // Public inputs after the images; see photoproof.EditParameters()
+}
+
+// ErrChainedBounds is returned when a photograph carries more than one bounded transformation.
+var ErrChainedBounds = errors.New("bounded transformations chained")
+
+// Transformations whose circuits only bound how far each channel moves. Chaining them adds up their bounds, e.g. k
+// brightness edits within ±5 shift the image by up to 5k, so VerifyEdits() accepts at most one of them per photograph.
+var boundedTransformations = map[string]bool{
+	(photoproof.BrightnessTransformation{}).GetType():   true,
+	(photoproof.PerturbationTransformation{}).GetType(): true,
+}
+
 type User struct {
-	sk signature.Signer
+	Registry *Registry // Secure Cameras this user trusts
+	Keys     *KeyStore // Verifying keys this user trusts
 }
 
 func NewUser() (User, error) {
-	sk, err := photoproof.NewSecretKey()
+	return User{Registry: NewRegistry(), Keys: NewKeyStore()}, nil
+}
+
+// Verifies a photograph against the user's registry of trusted cameras and returns the camera that took it.
+// Fails with ErrUnknownCamera if no registered camera signed the photo.
+// Photos carry no trusted capture time, so the camera's status and validity window are checked at verification
+// time: once a camera expires or is revoked, every photo it took is rejected, including photos taken while it was valid.
+func (user User) IdentifyCamera(photo camera.Photograph) (RegisteredCamera, error) {
+	if user.Registry == nil {
+		return RegisteredCamera{}, ErrUnknownCamera
+	}
+	if photo.Proof.Gnark_Proof == nil || photo.Proof.Public_Witness == nil {
+		return RegisteredCamera{}, fmt.Errorf("ERROR: photograph has no proof of originality.")
+	}
+
+	original, _, err := user.VerifyEdits(photo)
+	if err != nil {
+		return RegisteredCamera{}, err
+	}
+
+	for _, cam := range user.Registry.Cameras() {
+		// The camera's public key is part of the public witness, so only the camera that signed the photo matches it.
+		recreated_witness, err := RecreateWitness(original, cam.PublicKey)
+		if err != nil {
+			continue
+		}
+		same, err := CompareWitnesses(photo.Proof.Public_Witness, recreated_witness)
+		if err != nil {
+			return RegisteredCamera{}, err
+		}
+		if !same {
+			continue
+		}
+
+		// The capture time is not part of the signed statement, so the only time that can be checked is now
+		if err := cam.CheckValid(time.Now()); err != nil {
+			return cam, err
+		}
+
+		if _, err := user.VerifyOriginality(photo, original, cam.PublicKey); err != nil {
+			return cam, err
+		}
+
+		return cam, nil
+	}
+
+	return RegisteredCamera{}, ErrUnknownCamera
+}
+
+// Verifies a photograph, including every permissible transformation applied since it was taken.
+// The photograph is only accepted if it was signed by the camera holding cameraKey; photos signed by any other key are rejected.
+// Verifying keys are looked up in the user's KeyStore, never taken from the photograph.
+func (user User) VerifyPhotograph(photo camera.Photograph, cameraKey signature.PublicKey) (bool, error) {
+	original, _, err := user.VerifyEdits(photo)
 	if err != nil {
-		return User{}, err
+		return false, err
 	}
 
-	return User{sk: sk}, err
+	return user.VerifyOriginality(photo, original, cameraKey)
 }
 
-// Wrapper for Gnark's proof verification.
+// Verifies the proof of every edit of the photograph, from its current image back to the original photo, and
+// returns the original image along with the verified edits, in the order they were applied. The original only holds
+// its size and commitment; its pixels are never revealed.
+// At most one of the edits may be a bounded transformation (see boundedTransformations), since chaining them would
+// exceed the bound each of them proves. The edits' public parameters are verified but not judged: e.g. a curve edit may apply any LUT, so users who only
+// accept some curves compare VerifiedEdit.LUTCommitment() with the commitments of those curves.
+func (user User) VerifyEdits(photo camera.Photograph) (image.Image, []VerifiedEdit, error) {
+	// Never trust the commitment that comes with the image; recompute it from the pixels
+	if err := photo.Img.Validate(); err != nil {
+		return image.Image{}, nil, err
+	}
+	b, err := photo.Img.Commitment()
+	if err != nil {
+		return image.Image{}, nil, err
+	}
+
+	current := image.Image{Width: photo.Img.Width, Height: photo.Img.Height, PixelBytes: b}
+	verified := make([]VerifiedEdit, len(photo.Edits))
+	bounded := -1 // Index of the bounded edit, if any
+
+	for i := len(photo.Edits) - 1; i >= 0; i-- {
+		edit := photo.Edits[i]
+
+		in, out, err := photoproof.EditStatement(edit)
+		if err != nil {
+			return image.Image{}, nil, err
+		}
+
+		// Each edit must output the image the next edit (or the viewer) starts from
+		if !photoproof.SameCommitment(out, current) {
+			return image.Image{}, nil, fmt.Errorf("ERROR: edit %d does not output the image it is attached to.", i)
+		}
+
+		key, err := user.Keys.EditVerifyingKey(edit)
+		if err != nil {
+			return image.Image{}, nil, err
+		}
+
+		if boundedTransformations[key.Transformation] {
+			if bounded >= 0 {
+				return image.Image{}, nil, fmt.Errorf("ERROR: edits %d and %d: %w", i, bounded, ErrChainedBounds)
+			}
+			bounded = i
+		}
+
+		err = groth16.Verify(edit.Gnark_Proof, key.VerifyingKey, edit.Public_Witness)
+		if err != nil {
+			return image.Image{}, nil, fmt.Errorf("ERROR: edit %d: %w", i, err)
+		}
+
+		parameters, err := photoproof.EditParameters(edit)
+		if err != nil {
+			return image.Image{}, nil, err
+		}
+
+		verified[i] = VerifiedEdit{Type: key.Type, Transformation: key.Transformation, In: in, Out: out, Parameters: parameters}
+		current = in
+	}
+
+	return current, verified, nil
+}
+
+// Returns the public commitment of a curve edit to the LUT it applied; see photoproof.LUT.Commitment().
+func (edit VerifiedEdit) LUTCommitment() ([]byte, error) {
+	if edit.Transformation != (photoproof.CurveTransformation{}).GetType() || len(edit.Parameters) != 1 {
+		return nil, fmt.Errorf("ERROR: %s edit has no LUT.", edit.Type)
+	}
+
+	b := edit.Parameters[0].Bytes()
+	return b[:], nil
+}
+
+// Wrapper for Gnark's proof verification of the original photo, whose size and commitment are given by original,
+// e.g. as returned by VerifyEdits(). VerifyPhotograph() runs both; callers that need the verified edits call them in turn.
 // There are two options for verification showcased below for educational purposes:
 //  1. OPTION 1: Compare recreated_witness and public_witness first, then verify with the Public_Witness
 //  2. OPTION 2: Use the recreated_witness in groth16.Verify
-func (user User) VerifyPhotograph(photo camera.Photograph) (bool, error) {
+func (user User) VerifyOriginality(photo camera.Photograph, original image.Image, cameraKey signature.PublicKey) (bool, error) {
 	// Recreate the wintess
-	recreated_witness, err := RecreateWitness(photo, user.sk)
+	recreated_witness, err := RecreateWitness(original, cameraKey)
 	if err != nil {
 		return false, fmt.Errorf("ERROR: user.GetWitness(photo) while verifying proof..")
 	}
 
+	circuit := photoproof.IdentityCircuit{Img: image.FrImage{Size: original.Size()}}
+	vk, err := user.Keys.VerifyingKey(photo.Proof, circuit.GetType())
+	if err != nil {
+		return false, err
+	}
+
 	// OPTION 1: Compare recreated_witness and public_witness
-	// if CompareWitnesses(photo.Proof.Public_Witness, recreated_witness) {
-	// 	err := groth16.Verify(photo.Proof.Gnark_Proof, photo.Proof.Gnark_Keys.VerifyingKey, photo.Proof.Public_Witness)
+	// if same, _ := CompareWitnesses(photo.Proof.Public_Witness, recreated_witness); same {
+	// 	err := groth16.Verify(photo.Proof.Gnark_Proof, vk, photo.Proof.Public_Witness)
 	// 	if err != nil {
 	// 		fmt.Println("ERROR: VerifyGnarkProof failed.")
 	// 		return false, fmt.Errorf(err.Error())
@@ -43,7 +200,7 @@ func (user User) VerifyPhotograph(photo camera.Photograph) (bool, error) {
 	// }
 
 	// OPTION 2: use the recreated_witness in groth16.Verify
-	err = groth16.Verify(photo.Proof.Gnark_Proof, photo.Proof.Gnark_Keys.VerifyingKey, recreated_witness)
+	err = groth16.Verify(photo.Proof.Gnark_Proof, vk, recreated_witness)
 	if err != nil {
 		fmt.Println("ERROR: VerifyGnarkProof failed.")
 		return false, fmt.Errorf(err.Error())
@@ -0,0 +1,137 @@
+package viewer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/drakstik/PhotoGnark_V1/src/camera"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
+	"github.com/drakstik/PhotoGnark_V1/src/photoproof"
+)
+
+// Returns a photo taken by a camera for which the given transformations are permissible, along with a user trusting
+// the camera's keys. Photos are small, so proofs are quick.
+func newPhoto(t *testing.T, permissible ...photoproof.Transformation) (*camera.SecureCamera, camera.Photograph, User) {
+	t.Helper()
+
+	permissible = append([]photoproof.Transformation{photoproof.IdentityTransformation{}}, permissible...)
+	cam := camera.NewCamera(permissible, image.Size{Width: 4, Height: 4})
+	if cam.PCD_Keys == nil {
+		t.Fatal("could not generate the camera's PCD_Keys.")
+	}
+
+	photo, err := cam.Take_Random_Photo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := NewUser()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := user.Keys.AddPCDKeys(cam.PCD_Keys); err != nil {
+		t.Fatal(err)
+	}
+
+	return &cam, photo, user
+}
+
+func brighten(t *testing.T, cam *camera.SecureCamera, photo camera.Photograph, delta int) camera.Photograph {
+	t.Helper()
+
+	brightness, err := photoproof.NewBrightness(photo.Img, delta, 5, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edited, err := photo.Edit(brightness, cam.PCD_Keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return edited
+}
+
+func TestVerifyEditsBoundedChain(t *testing.T) {
+	cam, photo, user := newPhoto(t, photoproof.BrightnessTransformation{Bound: 5}, photoproof.InvertTransformation{})
+
+	once := brighten(t, cam, photo, 5)
+	if ok, err := user.VerifyPhotograph(once, cam.PublicKey()); !ok || err != nil {
+		t.Fatalf("a single brightness edit should verify: %v", err)
+	}
+
+	// Two edits within ±5 would brighten by 10
+	twice := brighten(t, cam, once, 5)
+	if _, _, err := user.VerifyEdits(twice); !errors.Is(err, ErrChainedBounds) {
+		t.Fatalf("two brightness edits should not verify: %v", err)
+	}
+
+	// Inverting in between does not hide the second edit
+	invert, err := photoproof.NewInvert(once.Img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inverted, err := once.Edit(invert, cam.PCD_Keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := user.VerifyEdits(brighten(t, cam, inverted, -5)); !errors.Is(err, ErrChainedBounds) {
+		t.Fatalf("brightness edits separated by another edit should not verify: %v", err)
+	}
+}
+
+func perturb(t *testing.T, cam *camera.SecureCamera, photo camera.Photograph) camera.Photograph {
+	t.Helper()
+
+	// Darken the first pixel's red channel by one
+	rgb := photo.Img.Pixels[0].RGB
+	rgb[0] = max(rgb[0], 1) - 1
+	pixels := append([]image.Pixel{image.NewPixel(rgb, photo.Img.Pixels[0].Loc)}, photo.Img.Pixels[1:]...)
+	result := image.Image{Width: photo.Img.Width, Height: photo.Img.Height, Pixels: pixels}
+
+	perturbation, err := photoproof.NewPerturbation(photo.Img, result, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edited, err := photo.Edit(perturbation, cam.PCD_Keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return edited
+}
+
+func TestVerifyEditsPerturbationChain(t *testing.T) {
+	cam, photo, user := newPhoto(t, photoproof.BrightnessTransformation{Bound: 5}, photoproof.PerturbationTransformation{Epsilon: 2})
+
+	once := perturb(t, cam, photo)
+	if ok, err := user.VerifyPhotograph(once, cam.PublicKey()); !ok || err != nil {
+		t.Fatalf("a single perturbation should verify: %v", err)
+	}
+
+	// Each perturbation within ±2 moves the pixel further, without limit
+	if _, _, err := user.VerifyEdits(perturb(t, cam, once)); !errors.Is(err, ErrChainedBounds) {
+		t.Fatalf("two perturbations should not verify: %v", err)
+	}
+
+	if _, _, err := user.VerifyEdits(brighten(t, cam, once, 5)); !errors.Is(err, ErrChainedBounds) {
+		t.Fatalf("a perturbation followed by a brightness edit should not verify: %v", err)
+	}
+}
+
+func TestIdentifyCameraNoProof(t *testing.T) {
+	cam, photo, user := newPhoto(t)
+	if err := user.Registry.Register(RegisteredCamera{ID: "cam", PublicKey: cam.PublicKey()}); err != nil {
+		t.Fatal(err)
+	}
+
+	if registered, err := user.IdentifyCamera(photo); err != nil || registered.ID != "cam" {
+		t.Fatalf("the photo should be identified as taken by the registered camera: %v", err)
+	}
+
+	photo.Proof = photoproof.Gnark_Proof{}
+	if _, err := user.IdentifyCamera(photo); err == nil {
+		t.Fatal("a photo without a proof should not be identified.")
+	}
+}
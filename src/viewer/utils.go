@@ -5,46 +5,59 @@ import (
 	"fmt"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
 	"github.com/consensys/gnark-crypto/signature"
 	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/frontend"
-	"github.com/drakstik/PhotoGnark_V1/src/camera"
+	"github.com/consensys/gnark/std/signature/eddsa"
+	"github.com/drakstik/PhotoGnark_V1/src/image"
 	"github.com/drakstik/PhotoGnark_V1/src/photoproof"
 )
 
-func RecreateWitness(photo camera.Photograph, sk signature.Signer) (witness.Witness, error) {
-
-	img := photo.Img
-
-	// Create a new Identity Transformation
-	transformation, err := photoproof.NewIdentity(img, sk)
-	if err != nil {
-		return nil, fmt.Errorf("ERROR: NewIdentity() while verifying proof.")
+// Recreates the public witness of an original photo's Identity Circuit, i.e. the camera's public key and the image's size and commitment.
+// Only the public part of the circuit is assigned, so the viewer never needs a signing key nor the original's pixels.
+func RecreateWitness(img image.Image, cameraKey signature.PublicKey) (witness.Witness, error) {
+	if cameraKey == nil {
+		return nil, fmt.Errorf("ERROR: no camera public key given while verifying proof.")
 	}
 
-	circuit, err := transformation.ToFr(sk, sk.Public().Bytes())
-	if err != nil {
-		return nil, fmt.Errorf("ERROR: img.ToBigEndian() while verifying proof..")
+	// Assign the camera's PK to its eddsa equivilant
+	var eddsa_PK eddsa.PublicKey
+	eddsa_PK.Assign(tedwards.BN254, cameraKey.Bytes())
+
+	circuit := &photoproof.IdentityCircuit{
+		PublicKey: eddsa_PK,
+		Img: image.FrImage{
+			Width:    img.Width,
+			Height:   img.Height,
+			ImgBytes: img.PixelBytes,
+		},
 	}
 
-	//Create the secret witness from the circuit
-	secret_witness, err := frontend.NewWitness(circuit, ecc.BN254.ScalarField())
+	// Create the public witness from the circuit
+	known_witness, err := frontend.NewWitness(circuit, ecc.BN254.ScalarField(), frontend.PublicOnly())
 	if err != nil {
 		fmt.Println("ERROR: frontend.NewWitness() while verifying proof...\n" + err.Error())
 		return nil, err
 	}
 
-	known_witness, err := secret_witness.Public()
-	if err != nil {
-		return nil, fmt.Errorf("ERROR: secret_witness.Public() while verifying proof..")
-	}
-
 	return known_witness, err
 }
 
-func CompareWitnesses(witness_1 witness.Witness, witness_2 witness.Witness) bool {
-	known_witness_binaries, _ := witness_1.MarshalBinary()
-	public_witness_binaries, _ := witness_2.MarshalBinary()
+// Reports whether two public witnesses assign the same public inputs. Missing witnesses are an error, not a mismatch.
+func CompareWitnesses(witness_1 witness.Witness, witness_2 witness.Witness) (bool, error) {
+	if witness_1 == nil || witness_2 == nil {
+		return false, fmt.Errorf("ERROR: no public witness given while comparing witnesses.")
+	}
+
+	known_witness_binaries, err := witness_1.MarshalBinary()
+	if err != nil {
+		return false, err
+	}
+	public_witness_binaries, err := witness_2.MarshalBinary()
+	if err != nil {
+		return false, err
+	}
 
-	return bytes.Equal(known_witness_binaries, public_witness_binaries)
+	return bytes.Equal(known_witness_binaries, public_witness_binaries), nil
 }